@@ -0,0 +1,332 @@
+package myrasec
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	settingsSourceDefault   = "default"
+	settingsSourceDomain    = "domain"
+	settingsSourceTag       = "tag"
+	settingsSourceSubdomain = "subdomain"
+)
+
+// dataSourceMyrasecEffectiveSettings ...
+func dataSourceMyrasecEffectiveSettings() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMyrasecEffectiveSettingsRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subdomain_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"settings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tag_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"tag_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+	}
+}
+
+// dataSourceMyrasecEffectiveSettingsRead ...
+func dataSourceMyrasecEffectiveSettingsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	f := prepareSettingsFilter(d.Get("filter"))
+	if f == nil {
+		f = &settingsFilter{}
+	}
+
+	layers, diags := listSettingsLayers(meta, f.subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	settingData := make([]any, 0)
+	for _, s := range resolveEffectiveSettings(layers) {
+		settingData = append(settingData, map[string]any{
+			"name":     s.name,
+			"value":    formatSettingsValue(s.value),
+			"source":   s.layer.source,
+			"tag_id":   s.layer.tagID,
+			"tag_name": s.layer.tagName,
+		})
+	}
+
+	if err := d.Set("settings", settingData); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// settingsLayer contains the settings that are defined on one level of the settings inheritance
+type settingsLayer struct {
+	source   string
+	tagID    int
+	tagName  string
+	settings map[string]any
+}
+
+// effectiveSetting is the resolved value of a setting together with the layer it came from
+type effectiveSetting struct {
+	name  string
+	value any
+	layer *settingsLayer
+}
+
+// listSettingsLayers returns the settings layers for the passed subdomain, ordered from the lowest to the highest precedence
+func listSettingsLayers(meta any, subDomainName string) ([]*settingsLayer, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	client := meta.(*myrasec.API)
+
+	domain, diags := findDomainForSettings(meta, subDomainName)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	layers := []*settingsLayer{}
+
+	generalDomainName := fmt.Sprintf("ALL-%d", domain.ID)
+	generalSettings, err := client.ListSettingsFull(domain.ID, generalDomainName, nil)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error fetching settings",
+			Detail:   formatError(err),
+		})
+		return nil, diags
+	}
+	layers = append(layers,
+		&settingsLayer{source: settingsSourceDefault, settings: extractSettingsMap(generalSettings, "parent")},
+		&settingsLayer{source: settingsSourceDomain, settings: extractSettingsMap(generalSettings, "domain")},
+	)
+
+	if myrasec.IsGeneralDomainName(subDomainName) {
+		return layers, diags
+	}
+
+	tags, diags := listConfigTagsForSubdomain(meta, domain.Name, subDomainName)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for _, tag := range tags {
+		tagSettings, err := client.ListTagSettingsMap(tag.ID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error fetching tag settings",
+				Detail:   formatError(err),
+			})
+			return nil, diags
+		}
+		layers = append(layers, &settingsLayer{
+			source:   settingsSourceTag,
+			tagID:    tag.ID,
+			tagName:  tag.Name,
+			settings: extractSettingsMap(tagSettings, "settings"),
+		})
+	}
+
+	subdomainSettings, err := client.ListSettingsFull(domain.ID, subDomainName, nil)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error fetching settings",
+			Detail:   formatError(err),
+		})
+		return nil, diags
+	}
+	layers = append(layers, &settingsLayer{source: settingsSourceSubdomain, settings: extractSettingsMap(subdomainSettings, "domain")})
+
+	return layers, diags
+}
+
+// findDomainForSettings returns the domain for the passed subdomain name or general domain name (ALL-0000)
+func findDomainForSettings(meta any, subDomainName string) (*myrasec.Domain, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !myrasec.IsGeneralDomainName(subDomainName) {
+		return findDomainBySubdomainName(meta, subDomainName)
+	}
+
+	client := meta.(*myrasec.API)
+
+	domainID, err := myrasec.ExtractDomainIdFromGeneralDomainName(subDomainName)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error parsing general domain name",
+			Detail:   formatError(err),
+		})
+		return nil, diags
+	}
+
+	domain, err := client.GetDomain(domainID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error fetching domain",
+			Detail:   formatError(err),
+		})
+		return nil, diags
+	}
+
+	return domain, diags
+}
+
+// listConfigTagsForSubdomain returns the CONFIG tags that are assigned to the passed subdomain.
+// Tags assigned to the domain come before tags assigned to the subdomain, both ordered by their sort value.
+func listConfigTagsForSubdomain(meta any, domainName string, subDomainName string) ([]*myrasec.Tag, diag.Diagnostics) {
+	tags, diags := listTags(meta, map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	domainTags := []*myrasec.Tag{}
+	subdomainTags := []*myrasec.Tag{}
+	for i := range tags {
+		tag := &tags[i]
+		if !strings.EqualFold(tag.Type, "CONFIG") {
+			continue
+		}
+
+		if tag.Global {
+			domainTags = append(domainTags, tag)
+			continue
+		}
+
+		for _, a := range tag.Assignments {
+			name := myrasec.RemoveTrailingDot(a.SubDomainName)
+			if strings.EqualFold(a.Type, "DOMAIN") && strings.EqualFold(name, myrasec.RemoveTrailingDot(domainName)) {
+				domainTags = append(domainTags, tag)
+				break
+			}
+			if strings.EqualFold(a.Type, "SUBDOMAIN") && strings.EqualFold(name, myrasec.RemoveTrailingDot(subDomainName)) {
+				subdomainTags = append(subdomainTags, tag)
+				break
+			}
+		}
+	}
+
+	bySort := func(list []*myrasec.Tag) func(i, j int) bool {
+		return func(i, j int) bool {
+			return list[i].Sort < list[j].Sort
+		}
+	}
+	sort.SliceStable(domainTags, bySort(domainTags))
+	sort.SliceStable(subdomainTags, bySort(subdomainTags))
+
+	return append(domainTags, subdomainTags...), diags
+}
+
+// extractSettingsMap returns the settings map stored under the passed key of a settings response
+func extractSettingsMap(response any, key string) map[string]any {
+	settings, ok := response.(*map[string]any)
+	if !ok || settings == nil {
+		return map[string]any{}
+	}
+
+	values, ok := (*settings)[key].(map[string]any)
+	if !ok {
+		return map[string]any{}
+	}
+
+	return values
+}
+
+// resolveEffectiveSettings returns the effective value of every setting, the layers have to be ordered from the lowest to the highest precedence
+func resolveEffectiveSettings(layers []*settingsLayer) []effectiveSetting {
+	resolved := map[string]effectiveSetting{}
+	for _, layer := range layers {
+		for name, value := range layer.settings {
+			if value == nil {
+				continue
+			}
+			resolved[name] = effectiveSetting{
+				name:  name,
+				value: value,
+				layer: layer,
+			}
+		}
+	}
+
+	result := make([]effectiveSetting, 0, len(resolved))
+	for _, s := range resolved {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+
+	return result
+}
+
+// formatSettingsValue returns the string representation of a setting value. Lists are returned as JSON encoded string.
+func formatSettingsValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
@@ -0,0 +1,141 @@
+package myrasec
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceMyrasecRedirectAnalysis ...
+func dataSourceMyrasecRedirectAnalysis() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMyrasecRedirectAnalysisRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subdomain_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"findings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"redirect_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"related_redirect_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"related_source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"message": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+	}
+}
+
+// dataSourceMyrasecRedirectAnalysisRead ...
+func dataSourceMyrasecRedirectAnalysisRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	f := prepareRedirectAnalysisFilter(d.Get("filter"))
+	if f == nil {
+		f = &redirectFilter{}
+	}
+
+	redirects, diags := listRedirects(meta, f.subDomainName, map[string]string{})
+	if diags.HasError() {
+		return diags
+	}
+
+	findingData := make([]any, 0)
+	for _, finding := range analyzeRedirects(redirects, f.subDomainName) {
+		findingData = append(findingData, map[string]any{
+			"type":                finding.Type,
+			"redirect_id":         finding.Redirect.ID,
+			"source":              finding.Redirect.Source,
+			"destination":         finding.Redirect.Destination,
+			"related_redirect_id": finding.Related.ID,
+			"related_source":      finding.Related.Source,
+			"path":                finding.Path,
+			"message":             finding.message(),
+		})
+	}
+
+	if err := d.Set("findings", findingData); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// prepareRedirectAnalysisFilter fetches the panic that can happen in parseRedirectAnalysisFilter
+func prepareRedirectAnalysisFilter(d any) *redirectFilter {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("[DEBUG] recovered in prepareRedirectAnalysisFilter", r)
+		}
+	}()
+
+	return parseRedirectAnalysisFilter(d)
+}
+
+// parseRedirectAnalysisFilter converts the filter data to a redirectFilter struct
+func parseRedirectAnalysisFilter(d any) *redirectFilter {
+	cfg := d.([]any)
+	f := &redirectFilter{}
+
+	m := cfg[0].(map[string]any)
+
+	subDomainName, ok := m["subdomain_name"]
+	if ok {
+		f.subDomainName = subDomainName.(string)
+	}
+
+	return f
+}
@@ -2,7 +2,10 @@ package myrasec
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
@@ -31,6 +34,20 @@ func dataSourceMyrasecSSLConfigurations() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"cipher_list": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"protocol_list": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 					},
 				},
 			},
@@ -51,9 +68,11 @@ func dataSourceMyrasecSSLConfigurationsRead(ctx context.Context, d *schema.Resou
 	configurationsData := make([]any, 0)
 	for _, c := range configurations {
 		data := map[string]any{
-			"name":      c.Name,
-			"ciphers":   c.Ciphers,
-			"protocols": c.Protocols,
+			"name":          c.Name,
+			"ciphers":       c.Ciphers,
+			"protocols":     c.Protocols,
+			"cipher_list":   splitSSLConfigurationList(c.Ciphers),
+			"protocol_list": splitSSLConfigurationList(c.Protocols),
 		}
 		configurationsData = append(configurationsData, data)
 	}
@@ -67,6 +86,7 @@ func dataSourceMyrasecSSLConfigurationsRead(ctx context.Context, d *schema.Resou
 	return diags
 }
 
+// listSslConfigurations ...
 func listSslConfigurations(meta any) ([]myrasec.SslConfiguration, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -83,3 +103,62 @@ func listSslConfigurations(meta any) ([]myrasec.SslConfiguration, diag.Diagnosti
 
 	return res, diags
 }
+
+// findSslConfiguration returns the SSL configuration with the passed name (case insensitive)
+func findSslConfiguration(meta any, name string) (*myrasec.SslConfiguration, diag.Diagnostics) {
+	configurations, diags := listSslConfigurations(meta)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for i, c := range configurations {
+		if strings.EqualFold(c.Name, name) {
+			return &configurations[i], diags
+		}
+	}
+
+	return nil, diags
+}
+
+// splitSSLConfigurationList splits the ciphers or protocols of a SSL configuration (OpenSSL format)
+func splitSSLConfigurationList(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == ':' || r == ','
+	})
+}
+
+// unsupportedTLSVersions returns the TLS versions that are not supported by the SSL configuration
+func unsupportedTLSVersions(configuration *myrasec.SslConfiguration, versions []string) []string {
+	protocols := splitSSLConfigurationList(configuration.Protocols)
+
+	unsupported := []string{}
+	for _, v := range versions {
+		if !slices.Contains(protocols, v) {
+			unsupported = append(unsupported, v)
+		}
+	}
+	return unsupported
+}
+
+// validateSSLConfigurationName checks the configuration_name against the available SSL configurations
+func validateSSLConfigurationName(d *schema.ResourceDiff, meta any) error {
+	name := d.Get("configuration_name").(string)
+	if name == "" || !d.HasChange("configuration_name") || !d.NewValueKnown("configuration_name") {
+		return nil
+	}
+
+	configurations, diags := listSslConfigurations(meta)
+	if diags.HasError() {
+		return fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	names := []string{}
+	for _, c := range configurations {
+		if strings.EqualFold(c.Name, name) {
+			return nil
+		}
+		names = append(names, c.Name)
+	}
+
+	return fmt.Errorf("configuration_name [%s] is not available, valid values are [%s]", name, strings.Join(names, ", "))
+}
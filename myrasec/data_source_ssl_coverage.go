@@ -0,0 +1,186 @@
+package myrasec
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceMyrasecSSLCoverage ...
+func dataSourceMyrasecSSLCoverage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMyrasecSSLCoverageRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"subdomains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subdomain_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"certificate_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"subject": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"managed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"valid_to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expired": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"uncovered": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+	}
+}
+
+// dataSourceMyrasecSSLCoverageRead ...
+func dataSourceMyrasecSSLCoverageRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	f := prepareSSLCoverageFilter(d.Get("filter"))
+	if f == nil {
+		f = &sslCoverageFilter{}
+	}
+
+	records, diags := listDnsRecords(meta, f.domainName, map[string]string{})
+	if diags.HasError() {
+		return diags
+	}
+
+	certificates, diags := listSSLCertificates(meta, f.domainName, map[string]string{})
+	if diags.HasError() {
+		return diags
+	}
+
+	subdomainData := make([]any, 0)
+	for _, name := range listServedSubdomains(records) {
+		data := map[string]any{
+			"subdomain_name": name,
+			"uncovered":      true,
+		}
+
+		cert := findSSLCertificateForSubdomain(certificates, name)
+		if cert != nil {
+			data["certificate_id"] = cert.ID
+			data["subject"] = cert.Subject
+			data["managed"] = cert.Managed
+			if cert.ValidTo != nil {
+				data["valid_to"] = cert.ValidTo.Format(time.RFC3339)
+				data["expired"] = isExpired(cert.ValidTo)
+			}
+			for _, san := range cert.SubjectAlternatives {
+				if sslNameMatches(san, name) {
+					data["uncovered"] = false
+					break
+				}
+			}
+		}
+
+		subdomainData = append(subdomainData, data)
+	}
+
+	if err := d.Set("subdomains", subdomainData); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// listServedSubdomains returns the names of all active (served by Myra) A, AAAA and CNAME records
+func listServedSubdomains(records []myrasec.DNSRecord) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, r := range records {
+		if !r.Active {
+			continue
+		}
+		switch r.RecordType {
+		case "A", "AAAA", "CNAME":
+		default:
+			continue
+		}
+
+		name := strings.ToLower(myrasec.RemoveTrailingDot(r.Name))
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// prepareSSLCoverageFilter fetches the panic that can happen in parseSSLCoverageFilter
+func prepareSSLCoverageFilter(d any) *sslCoverageFilter {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("[DEBUG] recovered in prepareSSLCoverageFilter", r)
+		}
+	}()
+
+	return parseSSLCoverageFilter(d)
+}
+
+// parseSSLCoverageFilter converts the filter data to a sslCoverageFilter struct
+func parseSSLCoverageFilter(d any) *sslCoverageFilter {
+	cfg := d.([]any)
+	f := &sslCoverageFilter{}
+
+	m := cfg[0].(map[string]any)
+
+	domainName, ok := m["domain_name"]
+	if ok {
+		f.domainName = domainName.(string)
+	}
+
+	return f
+}
+
+// sslCoverageFilter ...
+type sslCoverageFilter struct {
+	domainName string
+}
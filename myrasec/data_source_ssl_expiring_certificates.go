@@ -0,0 +1,197 @@
+package myrasec
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceMyrasecSSLExpiringCertificates ...
+func dataSourceMyrasecSSLExpiringCertificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMyrasecSSLExpiringCertificatesRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"domain_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"certificates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"subject": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subject_alternatives": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"subdomains": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"managed": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"valid_to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"days_remaining": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"expired": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+	}
+}
+
+// dataSourceMyrasecSSLExpiringCertificatesRead ...
+func dataSourceMyrasecSSLExpiringCertificatesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	f := prepareSSLExpiringCertificateFilter(d.Get("filter"))
+	if f == nil {
+		f = &sslExpiringCertificateFilter{}
+	}
+
+	domainNames := []string{}
+	if f.domainName != "" {
+		domainNames = append(domainNames, f.domainName)
+	} else {
+		domains, diags := listDomains(meta, map[string]string{})
+		if diags.HasError() {
+			return diags
+		}
+		for _, domain := range domains {
+			domainNames = append(domainNames, domain.Name)
+		}
+	}
+
+	limit := time.Now().AddDate(0, 0, f.days)
+
+	certificateData := make([]map[string]any, 0)
+	for _, domainName := range domainNames {
+		certificates, diags := listSSLCertificates(meta, domainName, map[string]string{})
+		if diags.HasError() {
+			return diags
+		}
+
+		for _, c := range certificates {
+			if c.ValidTo == nil || c.ValidTo.IsZero() || c.ValidTo.After(limit) {
+				continue
+			}
+
+			certificateData = append(certificateData, map[string]any{
+				"domain_name":          domainName,
+				"id":                   c.ID,
+				"subject":              c.Subject,
+				"subject_alternatives": c.SubjectAlternatives,
+				"subdomains":           c.Subdomains,
+				"managed":              c.Managed,
+				"valid_to":             c.ValidTo.Format(time.RFC3339),
+				"days_remaining":       daysUntil(c.ValidTo.Time),
+				"expired":              isExpired(c.ValidTo),
+			})
+		}
+	}
+
+	sort.SliceStable(certificateData, func(i, j int) bool {
+		return certificateData[i]["valid_to"].(string) < certificateData[j]["valid_to"].(string)
+	})
+
+	data := make([]any, 0, len(certificateData))
+	for _, c := range certificateData {
+		data = append(data, c)
+	}
+
+	if err := d.Set("certificates", data); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
+
+// prepareSSLExpiringCertificateFilter fetches the panic that can happen in parseSSLExpiringCertificateFilter
+func prepareSSLExpiringCertificateFilter(d any) *sslExpiringCertificateFilter {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("[DEBUG] recovered in prepareSSLExpiringCertificateFilter", r)
+		}
+	}()
+
+	return parseSSLExpiringCertificateFilter(d)
+}
+
+// parseSSLExpiringCertificateFilter converts the filter data to a sslExpiringCertificateFilter struct
+func parseSSLExpiringCertificateFilter(d any) *sslExpiringCertificateFilter {
+	cfg := d.([]any)
+	f := &sslExpiringCertificateFilter{}
+
+	m := cfg[0].(map[string]any)
+
+	days, ok := m["days"]
+	if ok {
+		f.days = days.(int)
+	}
+
+	domainName, ok := m["domain_name"]
+	if ok {
+		f.domainName = domainName.(string)
+	}
+
+	return f
+}
+
+// sslExpiringCertificateFilter ...
+type sslExpiringCertificateFilter struct {
+	days       int
+	domainName string
+}
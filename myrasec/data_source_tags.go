@@ -212,7 +212,7 @@ func listTags(meta any, params map[string]string) ([]myrasec.Tag, diag.Diagnosti
 			return tags, diags
 		}
 		tags = append(tags, res...)
-		if len(tags) < pageSize {
+		if len(res) < pageSize {
 			break
 		}
 		page++
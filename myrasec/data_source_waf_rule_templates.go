@@ -0,0 +1,136 @@
+package myrasec
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceMyrasecWAFRuleTemplates ...
+func dataSourceMyrasecWAFRuleTemplates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMyrasecWAFRuleTemplatesRead,
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subdomain_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"search": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"templates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dataSourceMyrasecWAFRules().Schema["waf_rules"].Elem,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+	}
+}
+
+// dataSourceMyrasecWAFRuleTemplatesRead ...
+func dataSourceMyrasecWAFRuleTemplatesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	f := prepareWAFRuleTemplateFilter(d.Get("filter"))
+	if f == nil {
+		f = &wafRuleTemplateFilter{}
+	}
+
+	params := map[string]string{}
+	if len(f.search) > 0 {
+		params["search"] = f.search
+	}
+
+	if len(f.subDomainName) > 0 {
+		params["subDomain"] = myrasec.EnsureTrailingDot(f.subDomainName)
+	}
+
+	rules, diags := listWAFRules(meta, f.subDomainName, params)
+	if diags.HasError() {
+		return diags
+	}
+
+	templateData := make([]any, 0)
+	for _, r := range rules {
+		if !r.Template {
+			continue
+		}
+		if len(f.name) > 0 && !strings.EqualFold(r.Name, f.name) {
+			continue
+		}
+		templateData = append(templateData, flattenWAFRuleData(&r))
+	}
+
+	if err := d.Set("templates", templateData); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return diags
+}
+
+// prepareWAFRuleTemplateFilter ...
+func prepareWAFRuleTemplateFilter(d any) *wafRuleTemplateFilter {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("[DEBUG] recovered in prepareWAFRuleTemplateFilter", r)
+		}
+	}()
+
+	return parseWAFRuleTemplateFilter(d)
+}
+
+// parseWAFRuleTemplateFilter ...
+func parseWAFRuleTemplateFilter(d any) *wafRuleTemplateFilter {
+	cfg := d.([]any)
+	f := &wafRuleTemplateFilter{}
+
+	m := cfg[0].(map[string]any)
+
+	subDomainName, ok := m["subdomain_name"]
+	if ok {
+		f.subDomainName = subDomainName.(string)
+	}
+
+	name, ok := m["name"]
+	if ok {
+		f.name = name.(string)
+	}
+
+	search, ok := m["search"]
+	if ok {
+		f.search = search.(string)
+	}
+
+	return f
+}
+
+// wafRuleTemplateFilter ...
+type wafRuleTemplateFilter struct {
+	subDomainName string
+	name          string
+	search        string
+}
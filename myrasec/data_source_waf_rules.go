@@ -203,59 +203,7 @@ func dataSourceMyrasecWAFRulesRead(ctx context.Context, d *schema.ResourceData,
 
 	ruleData := make([]any, 0)
 	for _, r := range rules {
-		data := map[string]any{
-			"id":             r.ID,
-			"created":        r.Created.Format(time.RFC3339),
-			"modified":       r.Modified.Format(time.RFC3339),
-			"subdomain_name": r.SubDomainName,
-			"description":    r.Description,
-			"direction":      r.Direction,
-			"enabled":        r.Enabled,
-			"log_identifier": r.LogIdentifier,
-			"name":           r.Name,
-			"process_next":   r.ProcessNext,
-			"rule_type":      r.RuleType,
-			"sort":           r.Sort,
-			"sync":           r.Sync,
-		}
-
-		if r.ExpireDate != nil {
-			data["expire_date"] = r.ExpireDate.Format(time.RFC3339)
-		}
-
-		if len(r.Conditions) > 0 {
-			conditions := make([]map[string]any, 0)
-			for _, c := range r.Conditions {
-				conditions = append(conditions, map[string]any{
-					"force_custom_values": c.ForceCustomValues,
-					"available_phases":    c.AvailablePhases,
-					"alias":               c.Alias,
-					"category":            c.Category,
-					"matching_type":       c.MatchingType,
-					"name":                c.Name,
-					"key":                 c.Key,
-					"value":               c.Value,
-				})
-			}
-			data["conditions"] = conditions
-		}
-
-		if len(r.Actions) > 0 {
-			actions := make([]map[string]any, 0)
-			for _, a := range r.Actions {
-				actions = append(actions, map[string]any{
-					"force_custom_values": a.ForceCustomValues,
-					"available_phases":    a.AvailablePhases,
-					"name":                a.Name,
-					"type":                a.Type,
-					"custom_key":          a.CustomKey,
-					"value":               a.Value,
-				})
-			}
-			data["actions"] = actions
-		}
-
-		ruleData = append(ruleData, data)
+		ruleData = append(ruleData, flattenWAFRuleData(&r))
 	}
 
 	if err := d.Set("waf_rules", ruleData); err != nil {
@@ -267,6 +215,63 @@ func dataSourceMyrasecWAFRulesRead(ctx context.Context, d *schema.ResourceData,
 	return diags
 }
 
+// flattenWAFRuleData returns the data source representation of a WAF rule
+func flattenWAFRuleData(r *myrasec.WAFRule) map[string]any {
+	data := map[string]any{
+		"id":             r.ID,
+		"created":        r.Created.Format(time.RFC3339),
+		"modified":       r.Modified.Format(time.RFC3339),
+		"subdomain_name": r.SubDomainName,
+		"description":    r.Description,
+		"direction":      r.Direction,
+		"enabled":        r.Enabled,
+		"log_identifier": r.LogIdentifier,
+		"name":           r.Name,
+		"process_next":   r.ProcessNext,
+		"rule_type":      r.RuleType,
+		"sort":           r.Sort,
+		"sync":           r.Sync,
+	}
+
+	if r.ExpireDate != nil {
+		data["expire_date"] = r.ExpireDate.Format(time.RFC3339)
+	}
+
+	if len(r.Conditions) > 0 {
+		conditions := make([]map[string]any, 0)
+		for _, c := range r.Conditions {
+			conditions = append(conditions, map[string]any{
+				"force_custom_values": c.ForceCustomValues,
+				"available_phases":    c.AvailablePhases,
+				"alias":               c.Alias,
+				"category":            c.Category,
+				"matching_type":       c.MatchingType,
+				"name":                c.Name,
+				"key":                 c.Key,
+				"value":               c.Value,
+			})
+		}
+		data["conditions"] = conditions
+	}
+
+	if len(r.Actions) > 0 {
+		actions := make([]map[string]any, 0)
+		for _, a := range r.Actions {
+			actions = append(actions, map[string]any{
+				"force_custom_values": a.ForceCustomValues,
+				"available_phases":    a.AvailablePhases,
+				"name":                a.Name,
+				"type":                a.Type,
+				"custom_key":          a.CustomKey,
+				"value":               a.Value,
+			})
+		}
+		data["actions"] = actions
+	}
+
+	return data
+}
+
 // listWAFRules ..
 func listWAFRules(meta any, subDomainName string, params map[string]string) ([]myrasec.WAFRule, diag.Diagnostics) {
 	var diags diag.Diagnostics
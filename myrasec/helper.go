@@ -3,13 +3,17 @@ package myrasec
 import (
 	"crypto/sha256"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/Myra-Security-GmbH/myrasec-go/v2/pkg/types"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // findDomainByDomainName ...
@@ -123,6 +127,25 @@ func findSubdomainNameAndDomainID(d *schema.ResourceData, meta any) (domainID in
 	return domainID, subDomainName, diags
 }
 
+// findDomainIDBySubdomainName returns the passed domain ID or looks up the domain ID of the subdomain if it is not set yet
+func findDomainIDBySubdomainName(meta any, subDomainName string, domainID int) (int, error) {
+	if domainID > 0 {
+		return domainID, nil
+	}
+
+	if myrasec.IsGeneralDomainName(subDomainName) && strings.HasPrefix(subDomainName, "ALL-") {
+		if id, err := myrasec.ExtractDomainIdFromGeneralDomainName(subDomainName); err == nil {
+			return id, nil
+		}
+	}
+
+	domain, diags := findDomainBySubdomainName(meta, subDomainName)
+	if diags.HasError() || domain == nil {
+		return 0, fmt.Errorf("unable to find domain for subdomain [%s]", subDomainName)
+	}
+	return domain.ID, nil
+}
+
 // findDomainBySubdomainName ...
 func findDomainBySubdomainName(meta any, subDomainName string) (*myrasec.Domain, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -181,6 +204,11 @@ func IntInSlice(needle int, haystack []int) bool {
 	return false
 }
 
+// isNotFoundError checks if the passed error is the response of the API to a request for an object that does not exist
+func isNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), fmt.Sprintf("(%d)", http.StatusNotFound))
+}
+
 // formatError returns the error message with a timestamp appended to it
 func formatError(err error) string {
 	return fmt.Sprintf("%s: %s", time.Now().Format(time.RFC3339Nano), err.Error())
@@ -191,3 +219,95 @@ func createContentHash(content string) string {
 	h.Write([]byte(content))
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
+
+// validateExpireDate checks that the passed expire date is a valid RFC3339 date and warns if it is already in the past
+func validateExpireDate(i any, k string) (warnings []string, errors []error) {
+	warnings, errors = validation.IsRFC3339Time(i, k)
+	if errors != nil {
+		return warnings, errors
+	}
+
+	expireDate, _ := types.ParseDate(i.(string))
+	if isExpired(expireDate) {
+		warnings = append(warnings, fmt.Sprintf("%q (%s) is in the past", k, i.(string)))
+	}
+
+	return warnings, errors
+}
+
+// suppressEquivalentDates suppresses the diff of two date strings (time.RFC3339) pointing to the same point in time
+func suppressEquivalentDates(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	oldDate, _ := types.ParseDate(oldValue)
+	newDate, _ := types.ParseDate(newValue)
+
+	return oldDate != nil && newDate != nil && oldDate.Equal(newDate.Time)
+}
+
+// isExpired checks if the passed expire date is set and in the past
+func isExpired(expireDate *types.DateTime) bool {
+	return expireDate != nil && !expireDate.IsZero() && expireDate.Before(time.Now())
+}
+
+// validateRemoveOnExpiry checks that an object with remove_on_expiry is not created or changed with an expire date in the past.
+// Such an object is removed from the state by the next refresh, so every apply would create it again.
+func validateRemoveOnExpiry(d *schema.ResourceDiff, name string) error {
+	if !d.Get("remove_on_expiry").(bool) || !d.NewValueKnown("expire_date") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("expire_date", "remove_on_expiry") {
+		return nil
+	}
+
+	expireDate, _ := types.ParseDate(d.Get("expire_date").(string))
+	if isExpired(expireDate) {
+		return fmt.Errorf("the expire_date [%s] of the %s is in the past and remove_on_expiry is set, the %s would be removed from the state again by the next refresh. Update the expire_date or remove the %s from the configuration", d.Get("expire_date").(string), name, name, name)
+	}
+	return nil
+}
+
+// daysUntil returns the number of full days until the passed time, negative if the time is in the past
+func daysUntil(t time.Time) int {
+	return int(time.Until(t).Hours() / 24)
+}
+
+// checkExpireDate returns a warning if the passed expire date is in the past or within the passed number of days.
+// The hint is appended to the warning and tells how to resolve it.
+func checkExpireDate(name string, expireDate *types.DateTime, warningDays int, hint string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if expireDate == nil || expireDate.IsZero() {
+		return diags
+	}
+
+	if isExpired(expireDate) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s has expired", name),
+			Detail:   fmt.Sprintf("%s expired on [%s]. %s", name, expireDate.Format(time.RFC3339), hint),
+		})
+		return diags
+	}
+
+	if warningDays > 0 && expireDate.Before(time.Now().AddDate(0, 0, warningDays)) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s expires soon", name),
+			Detail:   fmt.Sprintf("%s expires on [%s], which is within the next %d days. %s", name, expireDate.Format(time.RFC3339), warningDays, hint),
+		})
+	}
+
+	return diags
+}
+
+// rawConfigString returns the configured value of the passed attribute. The second return value is false if the value is not known yet
+func rawConfigString(config cty.Value, key string) (string, bool) {
+	if config.IsNull() || !config.IsKnown() {
+		return "", false
+	}
+
+	value := config.GetAttr(key)
+	if value.IsNull() || !value.IsKnown() {
+		return "", value.IsKnown()
+	}
+	return value.AsString(), true
+}
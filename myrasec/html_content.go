@@ -0,0 +1,392 @@
+package myrasec
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/net/html"
+)
+
+var (
+	cssCommentRegexp    = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	cssWhitespaceRegexp = regexp.MustCompile(`\s*([{};,])\s*`)
+	cssURLRegexp        = regexp.MustCompile(`url\(\s*(['"]?)([^'")]+)(['"]?)\s*\)`)
+	whitespaceRegexp    = regexp.MustCompile(`\s+`)
+)
+
+// voidElements are HTML elements without an end tag
+var voidElements = []string{"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "param", "source", "track", "wbr"}
+
+// optionalEndTagElements are HTML elements where the end tag can be omitted
+var optionalEndTagElements = []string{"html", "head", "body", "p", "li", "dt", "dd", "option", "optgroup", "colgroup", "caption", "thead", "tbody", "tfoot", "tr", "td", "th", "rb", "rt", "rtc", "rp"}
+
+// htmlContentSourceDirSchema returns the schema of the content_source_dir attribute
+func htmlContentSourceDirSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Directory of the local CSS, JavaScript and image files that are referenced by the content. The files are inlined into the content.",
+	}
+}
+
+// suppressRenderedContentDiff suppresses the diff of the content if the hash of the rendered content matches the content_hash
+func suppressRenderedContentDiff(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	// the content has to be rendered with the configured source directory, not with the one of the state
+	sourceDir := d.Get("content_source_dir").(string)
+	if raw := d.GetRawConfig(); !raw.IsNull() {
+		dir, ok := rawConfigString(raw, "content_source_dir")
+		if !ok {
+			return false
+		}
+		sourceDir = dir
+	}
+
+	content, err := renderHTMLContent(newValue, sourceDir)
+	if err != nil {
+		return false
+	}
+	return d.Get("content_hash") == createContentHash(content)
+}
+
+// resourceCustomizeDiffHTMLContent renders the content and validates the result before it is uploaded
+func resourceCustomizeDiffHTMLContent(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if !d.NewValueKnown("content") || !d.NewValueKnown("content_source_dir") {
+		return nil
+	}
+
+	content := d.Get("content").(string)
+	if content == "" {
+		return nil
+	}
+
+	sourceDir := d.Get("content_source_dir").(string)
+	if sourceDir == "" {
+		return nil
+	}
+
+	rendered, err := renderHTMLContent(content, sourceDir)
+	if err != nil {
+		return fmt.Errorf("unable to render content: %s", err.Error())
+	}
+
+	if err := validateHTMLContent(rendered); err != nil {
+		return fmt.Errorf("content is not well-formed HTML: %s", err.Error())
+	}
+
+	return nil
+}
+
+// renderHTMLContent inlines the local assets from the source directory and minifies the content. Without a source directory the content is returned unchanged
+func renderHTMLContent(content string, sourceDir string) (string, error) {
+	if sourceDir == "" {
+		return content, nil
+	}
+
+	var buf bytes.Buffer
+	var rawTag string
+
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			if errors.Is(z.Err(), io.EOF) {
+				break
+			}
+			return "", z.Err()
+		}
+
+		raw := string(z.Raw())
+		token := z.Token()
+
+		switch tt {
+		case html.CommentToken:
+			// keep conditional comments
+			if strings.HasPrefix(strings.TrimSpace(token.Data), "[if") {
+				buf.WriteString(raw)
+			}
+		case html.TextToken:
+			switch rawTag {
+			case "style":
+				css, err := inlineCSSAssets(raw, sourceDir)
+				if err != nil {
+					return "", err
+				}
+				buf.WriteString(minifyCSS(css))
+			case "script":
+				buf.WriteString(strings.TrimSpace(raw))
+			case "pre", "textarea":
+				buf.WriteString(raw)
+			default:
+				text := whitespaceRegexp.ReplaceAllString(raw, " ")
+				if bytes.HasSuffix(buf.Bytes(), []byte(" ")) {
+					text = strings.TrimLeft(text, " ")
+				}
+				buf.WriteString(text)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if tt == html.StartTagToken && StringInSlice(token.Data, []string{"style", "script", "pre", "textarea"}) {
+				rawTag = token.Data
+			}
+
+			inlined, err := inlineHTMLTag(&token, sourceDir)
+			if err != nil {
+				return "", err
+			}
+			if inlined == "" {
+				buf.WriteString(raw)
+			} else {
+				buf.WriteString(inlined)
+			}
+		case html.EndTagToken:
+			if token.Data == rawTag {
+				rawTag = ""
+			}
+			buf.WriteString(raw)
+		default:
+			buf.WriteString(raw)
+		}
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// inlineHTMLTag replaces the references to local files of the passed tag. It returns an empty string if the tag is not changed
+func inlineHTMLTag(token *html.Token, sourceDir string) (string, error) {
+	changed := false
+
+	// stylesheets are replaced by style elements
+	if token.Data == "link" && strings.EqualFold(htmlAttribute(token, "rel"), "stylesheet") {
+		href := htmlAttribute(token, "href")
+		if isLocalAsset(href) {
+			path := filepath.Join(sourceDir, stripAssetQuery(href))
+			css, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+
+			inlined, err := inlineCSSAssets(string(css), filepath.Dir(path))
+			if err != nil {
+				return "", err
+			}
+
+			return "<style>" + minifyCSS(inlined) + "</style>", nil
+		}
+	}
+
+	for i, attr := range token.Attr {
+		switch {
+		case attr.Key == "src" && token.Data == "script":
+			if !isLocalAsset(attr.Val) {
+				continue
+			}
+			js, err := os.ReadFile(filepath.Join(sourceDir, stripAssetQuery(attr.Val)))
+			if err != nil {
+				return "", err
+			}
+			token.Attr = append(token.Attr[:i], token.Attr[i+1:]...)
+			script := strings.ReplaceAll(strings.TrimSpace(string(js)), "</script", `<\/script`)
+			return token.String() + script, nil
+		case attr.Key == "src" || attr.Key == "poster" || (attr.Key == "href" && token.Data == "link"):
+			if !isLocalAsset(attr.Val) {
+				continue
+			}
+			uri, err := assetDataURI(filepath.Join(sourceDir, stripAssetQuery(attr.Val)))
+			if err != nil {
+				return "", err
+			}
+			token.Attr[i].Val = uri
+			changed = true
+		case attr.Key == "style":
+			css, err := inlineCSSAssets(attr.Val, sourceDir)
+			if err != nil {
+				return "", err
+			}
+			if css != attr.Val {
+				token.Attr[i].Val = css
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return "", nil
+	}
+	return token.String(), nil
+}
+
+// inlineCSSAssets replaces the url() references to local files of the passed CSS with data URIs
+func inlineCSSAssets(css string, sourceDir string) (string, error) {
+	var err error
+
+	inlined := cssURLRegexp.ReplaceAllStringFunc(css, func(match string) string {
+		ref := cssURLRegexp.FindStringSubmatch(match)[2]
+		if err != nil || !isLocalAsset(ref) {
+			return match
+		}
+
+		uri, e := assetDataURI(filepath.Join(sourceDir, stripAssetQuery(ref)))
+		if e != nil {
+			err = e
+			return match
+		}
+		return fmt.Sprintf("url(%q)", uri)
+	})
+
+	return inlined, err
+}
+
+// minifyCSS removes comments and unnecessary whitespace from the passed CSS. Quoted strings are kept unchanged
+func minifyCSS(css string) string {
+	var buf strings.Builder
+
+	for css != "" {
+		i := strings.IndexAny(css, `"'`)
+		if i < 0 {
+			buf.WriteString(minifyCSSSegment(css))
+			break
+		}
+
+		// a quote within a comment does not start a string
+		if c := strings.Index(css, "/*"); c >= 0 && c < i {
+			end := len(css)
+			if e := strings.Index(css[c+2:], "*/"); e >= 0 {
+				end = c + 2 + e + 2
+			}
+			buf.WriteString(minifyCSSSegment(css[:c]) + " ")
+			css = css[end:]
+			continue
+		}
+
+		buf.WriteString(minifyCSSSegment(css[:i]))
+
+		end := cssStringEnd(css, i)
+		buf.WriteString(css[i:end])
+		css = css[end:]
+	}
+
+	return strings.TrimSpace(buf.String())
+}
+
+// minifyCSSSegment removes comments and unnecessary whitespace from the passed CSS that contains no quoted strings
+func minifyCSSSegment(css string) string {
+	css = cssCommentRegexp.ReplaceAllString(css, "")
+	css = whitespaceRegexp.ReplaceAllString(css, " ")
+	return cssWhitespaceRegexp.ReplaceAllString(css, "$1")
+}
+
+// cssStringEnd returns the index after the quoted string that starts at the passed index. Escaped quotes are part of the string
+func cssStringEnd(css string, start int) int {
+	quote := css[start]
+	for i := start + 1; i < len(css); i++ {
+		switch css[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(css)
+}
+
+// assetDataURI returns the content of the passed file as base64 encoded data URI
+func assetDataURI(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data)), nil
+}
+
+// isLocalAsset checks if the passed reference points to a local file
+func isLocalAsset(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "//") || strings.HasPrefix(ref, "/") {
+		return false
+	}
+
+	scheme, _, found := strings.Cut(ref, ":")
+	return !found || strings.ContainsAny(scheme, "/?#.")
+}
+
+// stripAssetQuery removes the query and fragment from the passed reference
+func stripAssetQuery(ref string) string {
+	ref, _, _ = strings.Cut(strings.TrimSpace(ref), "#")
+	ref, _, _ = strings.Cut(ref, "?")
+	return ref
+}
+
+// htmlAttribute returns the value of the attribute with the passed key
+func htmlAttribute(token *html.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// validateHTMLContent checks that all elements of the passed content are closed in the right order
+func validateHTMLContent(content string) error {
+	var open []string
+
+	z := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			if !errors.Is(z.Err(), io.EOF) {
+				return z.Err()
+			}
+			for _, tag := range open {
+				if !StringInSlice(tag, optionalEndTagElements) {
+					return fmt.Errorf("element <%s> is not closed", tag)
+				}
+			}
+			return nil
+		case html.StartTagToken:
+			name, _ := z.TagName()
+			if !StringInSlice(string(name), voidElements) {
+				open = append(open, string(name))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			tag := string(name)
+			if StringInSlice(tag, voidElements) {
+				continue
+			}
+
+			for {
+				if len(open) == 0 {
+					return fmt.Errorf("unexpected end tag </%s>", tag)
+				}
+
+				last := open[len(open)-1]
+				open = open[:len(open)-1]
+				if last == tag {
+					break
+				}
+				if !StringInSlice(last, optionalEndTagElements) {
+					return fmt.Errorf("unexpected end tag </%s>, expected </%s>", tag, last)
+				}
+			}
+		}
+	}
+}
@@ -43,42 +43,56 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"myrasec_domains":               dataSourceMyrasecDomains(),
-			"myrasec_dns_records":           dataSourceMyrasecDNSRecords(),
-			"myrasec_cache_settings":        dataSourceMyrasecCacheSettings(),
-			"myrasec_redirects":             dataSourceMyrasecRedirects(),
-			"myrasec_settings":              dataSourceMyrasecSettings(),
-			"myrasec_ip_filters":            dataSourceMyrasecIPFilters(),
-			"myrasec_waf_rules":             dataSourceMyrasecWAFRules(),
-			"myrasec_waf_conditions":        dataSourceMyrasecWAFConditions(),
-			"myrasec_waf_actions":           dataSourceMyrasecWAFActions(),
-			"myrasec_ip_ranges":             dataSourceMyrasecIPRanges(),
-			"myrasec_ssl_certificates":      dataSourceMyrasecSSLCertificates(),
-			"myrasec_ssl_configurations":    dataSourceMyrasecSSLConfigurations(),
-			"myrasec_error_pages":           dataSourceMyrasecErrorPages(),
-			"myrasec_maintenances":          dataSourceMyrasecMaintenances(),
-			"myrasec_maintenance_templates": dataSourceMyrasecMaintenanceTemplates(),
-			"myrasec_tags":                  dataSourceMyrasecTags(),
-			"myrasec_tag_cache_settings":    dataSourceMyrasecTagCacheSettings(),
-			"myrasec_tag_information":       dataSourceMyrasecTagInformation(),
-			"myrasec_tag_settings":          dataSourceMyrasecTagSettings(),
-			"myrasec_tag_waf_rules":         dataSourceMyrasecTagWAFRules(),
-			"myrasec_waitingrooms":          dataSourceMyrasecWaitingRooms(),
-			"myrasec_api_keys":              dataSourceMyrasecApiKeys(),
+			"myrasec_domains":                   dataSourceMyrasecDomains(),
+			"myrasec_dns_records":               dataSourceMyrasecDNSRecords(),
+			"myrasec_cache_settings":            dataSourceMyrasecCacheSettings(),
+			"myrasec_redirects":                 dataSourceMyrasecRedirects(),
+			"myrasec_redirect_analysis":         dataSourceMyrasecRedirectAnalysis(),
+			"myrasec_settings":                  dataSourceMyrasecSettings(),
+			"myrasec_effective_settings":        dataSourceMyrasecEffectiveSettings(),
+			"myrasec_ip_filters":                dataSourceMyrasecIPFilters(),
+			"myrasec_waf_rules":                 dataSourceMyrasecWAFRules(),
+			"myrasec_waf_rule_templates":        dataSourceMyrasecWAFRuleTemplates(),
+			"myrasec_waf_conditions":            dataSourceMyrasecWAFConditions(),
+			"myrasec_waf_actions":               dataSourceMyrasecWAFActions(),
+			"myrasec_ip_ranges":                 dataSourceMyrasecIPRanges(),
+			"myrasec_ssl_certificates":          dataSourceMyrasecSSLCertificates(),
+			"myrasec_ssl_configurations":        dataSourceMyrasecSSLConfigurations(),
+			"myrasec_ssl_coverage":              dataSourceMyrasecSSLCoverage(),
+			"myrasec_ssl_expiring_certificates": dataSourceMyrasecSSLExpiringCertificates(),
+			"myrasec_error_pages":               dataSourceMyrasecErrorPages(),
+			"myrasec_maintenances":              dataSourceMyrasecMaintenances(),
+			"myrasec_maintenance_templates":     dataSourceMyrasecMaintenanceTemplates(),
+			"myrasec_tags":                      dataSourceMyrasecTags(),
+			"myrasec_tag_cache_settings":        dataSourceMyrasecTagCacheSettings(),
+			"myrasec_tag_information":           dataSourceMyrasecTagInformation(),
+			"myrasec_tag_settings":              dataSourceMyrasecTagSettings(),
+			"myrasec_tag_waf_rules":             dataSourceMyrasecTagWAFRules(),
+			"myrasec_waitingrooms":              dataSourceMyrasecWaitingRooms(),
+			"myrasec_api_keys":                  dataSourceMyrasecApiKeys(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"myrasec_domain":               resourceMyrasecDomain(),
 			"myrasec_dns_record":           resourceMyrasecDNSRecord(),
 			"myrasec_cache_setting":        resourceMyrasecCacheSetting(),
+			"myrasec_cache_rule_set":       resourceMyrasecCacheRuleSet(),
+			"myrasec_cache_purge":          resourceMyrasecCachePurge(),
 			"myrasec_redirect":             resourceMyrasecRedirect(),
+			"myrasec_redirect_map":         resourceMyrasecRedirectMap(),
 			"myrasec_settings":             resourceMyrasecSettings(),
 			"myrasec_ip_filter":            resourceMyrasecIPFilter(),
+			"myrasec_ip_filter_list":       resourceMyrasecIPFilterList(),
 			"myrasec_waf_rule":             resourceMyrasecWAFRule(),
 			"myrasec_ssl_certificate":      resourceMyrasecSSLCertificate(),
+			"myrasec_acme_certificate":     resourceMyrasecACMECertificate(),
 			"myrasec_error_page":           resourceMyrasecErrorPage(),
+			"myrasec_error_page_set":       resourceMyrasecErrorPageSet(),
 			"myrasec_maintenance":          resourceMyrasecMaintenance(),
+			"myrasec_maintenance_schedule": resourceMyrasecMaintenanceSchedule(),
 			"myrasec_maintenance_template": resourceMyrasecMaintenanceTemplate(),
 			"myrasec_tag":                  resourceMyrasecTag(),
+			"myrasec_tag_assignment":       resourceMyrasecTagAssignment(),
+			"myrasec_tag_bundle":           resourceMyrasecTagBundle(),
 			"myrasec_tag_cache_setting":    resourceMyrasecTagCacheSetting(),
 			"myrasec_tag_information":      resourceMyrasecTagInformation(),
 			"myrasec_tag_waf_rule":         resourceMyrasecTagWAFRule(),
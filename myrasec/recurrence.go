@@ -0,0 +1,367 @@
+package myrasec
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrenceDays limits the search for the next occurrences of a recurrence
+const maxRecurrenceDays = 5 * 366
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+var cronWeekdays = map[string]int{"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6}
+
+var cronMonths = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+// recurrence is a parsed cron expression or RRULE
+type recurrence struct {
+	matchesDay func(day time.Time) bool
+	hours      []int
+	minutes    []int
+	notBefore  time.Time
+}
+
+// recurrenceWindow is a single occurrence of a recurrence
+type recurrenceWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// parseRecurrence parses a cron expression (minute hour day-of-month month day-of-week) or a RRULE (FREQ=...).
+// The anchor is used as DTSTART of a RRULE
+func parseRecurrence(value string, anchor time.Time) (*recurrence, error) {
+	var r *recurrence
+	var err error
+
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if strings.HasPrefix(value, "RRULE:") || strings.Contains(value, "FREQ=") {
+		r, err = parseRRule(strings.TrimPrefix(value, "RRULE:"), anchor)
+	} else {
+		r, err = parseCron(value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.notBefore = anchor
+	return r, nil
+}
+
+// parseCron parses a cron expression with the fields minute, hour, day-of-month, month and day-of-week
+func parseCron(value string) (*recurrence, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression [%s] must have 5 fields (minute hour day-of-month month day-of-week)", value)
+	}
+
+	minutes, err := parseCronField(fields[0], 0, 59, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minute field: %s", err.Error())
+	}
+	hours, err := parseCronField(fields[1], 0, 23, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hour field: %s", err.Error())
+	}
+	days, err := parseCronField(fields[2], 1, 31, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %s", err.Error())
+	}
+	months, err := parseCronField(fields[3], 1, 12, cronMonths)
+	if err != nil {
+		return nil, fmt.Errorf("invalid month field: %s", err.Error())
+	}
+	weekdays, err := parseCronField(fields[4], 0, 7, cronWeekdays)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %s", err.Error())
+	}
+	// 7 is an alias for sunday
+	if IntInSlice(7, weekdays) {
+		weekdays = append(weekdays, 0)
+	}
+
+	anyDay := fields[2] == "*"
+	anyWeekday := fields[4] == "*"
+
+	return &recurrence{
+		matchesDay: func(day time.Time) bool {
+			if !IntInSlice(int(day.Month()), months) {
+				return false
+			}
+			dayMatch := IntInSlice(day.Day(), days)
+			weekdayMatch := IntInSlice(int(day.Weekday()), weekdays)
+			// like cron, a day matches if either day-of-month or day-of-week matches when both are restricted
+			if !anyDay && !anyWeekday {
+				return dayMatch || weekdayMatch
+			}
+			return dayMatch && weekdayMatch
+		},
+		hours:   hours,
+		minutes: minutes,
+	}, nil
+}
+
+// parseCronField parses a single cron field supporting *, lists, ranges, steps and names
+func parseCronField(field string, min int, max int, names map[string]int) ([]int, error) {
+	var values []int
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if rangePart, stepPart, ok := strings.Cut(part, "/"); ok {
+			s, err := strconv.Atoi(stepPart)
+			if err != nil || s < 1 {
+				return nil, fmt.Errorf("invalid step [%s]", stepPart)
+			}
+			step = s
+			part = rangePart
+		}
+
+		from, to := min, max
+		if part != "*" {
+			fromPart, toPart, isRange := strings.Cut(part, "-")
+			f, err := parseCronValue(fromPart, min, max, names)
+			if err != nil {
+				return nil, err
+			}
+			from, to = f, f
+			if isRange {
+				if to, err = parseCronValue(toPart, min, max, names); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				to = max
+			}
+			if to < from {
+				return nil, fmt.Errorf("invalid range [%s]", part)
+			}
+		}
+
+		for v := from; v <= to; v += step {
+			if !IntInSlice(v, values) {
+				values = append(values, v)
+			}
+		}
+	}
+
+	sort.Ints(values)
+	return values, nil
+}
+
+// parseCronValue parses a single number or name of a cron field
+func parseCronValue(value string, min int, max int, names map[string]int) (int, error) {
+	if v, ok := names[value]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("value [%s] is not between %d and %d", value, min, max)
+	}
+	return v, nil
+}
+
+// parseRRule parses a RRULE with FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY, BYMONTHDAY, BYHOUR and BYMINUTE
+func parseRRule(value string, anchor time.Time) (*recurrence, error) {
+	parts := map[string]string{}
+	for _, part := range strings.Split(value, ";") {
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid RRULE part [%s]", part)
+		}
+		parts[k] = v
+	}
+
+	for k := range parts {
+		if !StringInSlice(k, []string{"FREQ", "INTERVAL", "BYDAY", "BYMONTHDAY", "BYHOUR", "BYMINUTE"}) {
+			return nil, fmt.Errorf("RRULE part [%s] is not supported", k)
+		}
+	}
+
+	freq := parts["FREQ"]
+	if !StringInSlice(freq, []string{"DAILY", "WEEKLY", "MONTHLY"}) {
+		return nil, fmt.Errorf("RRULE FREQ must be DAILY, WEEKLY or MONTHLY")
+	}
+
+	interval := 1
+	if v, ok := parts["INTERVAL"]; ok {
+		i, err := strconv.Atoi(v)
+		if err != nil || i < 1 {
+			return nil, fmt.Errorf("invalid RRULE INTERVAL [%s]", v)
+		}
+		interval = i
+	}
+	if interval > 1 && anchor.IsZero() {
+		return nil, fmt.Errorf("RRULE INTERVAL requires a start date")
+	}
+
+	hours := []int{anchor.Hour()}
+	if v, ok := parts["BYHOUR"]; ok {
+		h, err := parseCronField(v, 0, 23, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE BYHOUR: %s", err.Error())
+		}
+		hours = h
+	}
+
+	minutes := []int{anchor.Minute()}
+	if v, ok := parts["BYMINUTE"]; ok {
+		m, err := parseCronField(v, 0, 59, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE BYMINUTE: %s", err.Error())
+		}
+		minutes = m
+	}
+
+	type byDay struct {
+		weekday time.Weekday
+		ordinal int
+	}
+	var byDays []byDay
+	if v, ok := parts["BYDAY"]; ok {
+		for _, day := range strings.Split(v, ",") {
+			if len(day) < 2 {
+				return nil, fmt.Errorf("invalid RRULE BYDAY [%s]", day)
+			}
+			weekday, ok := recurrenceWeekdays[day[len(day)-2:]]
+			if !ok {
+				return nil, fmt.Errorf("invalid RRULE BYDAY [%s]", day)
+			}
+			ordinal := 0
+			if len(day) > 2 {
+				o, err := strconv.Atoi(day[:len(day)-2])
+				if err != nil || o == 0 || o < -5 || o > 5 || freq != "MONTHLY" {
+					return nil, fmt.Errorf("invalid RRULE BYDAY [%s]", day)
+				}
+				ordinal = o
+			}
+			byDays = append(byDays, byDay{weekday: weekday, ordinal: ordinal})
+		}
+	}
+
+	var byMonthDays []int
+	if v, ok := parts["BYMONTHDAY"]; ok {
+		for _, day := range strings.Split(v, ",") {
+			d, err := strconv.Atoi(day)
+			if err != nil || d == 0 || d < -31 || d > 31 {
+				return nil, fmt.Errorf("invalid RRULE BYMONTHDAY [%s]", day)
+			}
+			byMonthDays = append(byMonthDays, d)
+		}
+	}
+
+	if freq == "WEEKLY" && len(byDays) == 0 {
+		if anchor.IsZero() {
+			return nil, fmt.Errorf("RRULE FREQ=WEEKLY requires BYDAY or a start date")
+		}
+		byDays = append(byDays, byDay{weekday: anchor.Weekday()})
+	}
+	if freq == "MONTHLY" && len(byDays) == 0 && len(byMonthDays) == 0 {
+		if anchor.IsZero() {
+			byMonthDays = append(byMonthDays, 1)
+		} else {
+			byMonthDays = append(byMonthDays, anchor.Day())
+		}
+	}
+
+	return &recurrence{
+		matchesDay: func(day time.Time) bool {
+			if !anchor.IsZero() {
+				start := time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, day.Location())
+				if day.Before(start) {
+					return false
+				}
+
+				var period int
+				switch freq {
+				case "DAILY":
+					period = int(day.Sub(start).Hours()+12) / 24
+				case "WEEKLY":
+					period = (int(day.Sub(start).Hours()+12)/24 + (int(start.Weekday())+6)%7) / 7
+				case "MONTHLY":
+					period = (day.Year()-start.Year())*12 + int(day.Month()) - int(start.Month())
+				}
+				if period%interval != 0 {
+					return false
+				}
+			}
+
+			if len(byMonthDays) > 0 {
+				lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+				match := false
+				for _, d := range byMonthDays {
+					if d == day.Day() || (d < 0 && lastDay+d+1 == day.Day()) {
+						match = true
+					}
+				}
+				if !match {
+					return false
+				}
+			}
+
+			if len(byDays) > 0 {
+				lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+				match := false
+				for _, d := range byDays {
+					if d.weekday != day.Weekday() {
+						continue
+					}
+					switch {
+					case d.ordinal == 0,
+						d.ordinal > 0 && (day.Day()-1)/7+1 == d.ordinal,
+						d.ordinal < 0 && (lastDay-day.Day())/7+1 == -d.ordinal:
+						match = true
+					}
+				}
+				if !match {
+					return false
+				}
+			}
+
+			return true
+		},
+		hours:   hours,
+		minutes: minutes,
+	}, nil
+}
+
+// nextOccurrences returns the next count windows of the recurrence that end after the passed time
+func (r *recurrence) nextOccurrences(after time.Time, duration time.Duration, count int, loc *time.Location) []recurrenceWindow {
+	var windows []recurrenceWindow
+
+	after = after.In(loc)
+	// start one day before the earliest window that can still be active
+	first := after.Add(-duration)
+	day := time.Date(first.Year(), first.Month(), first.Day()-1, 0, 0, 0, 0, loc)
+
+	for i := 0; i <= maxRecurrenceDays+int(duration.Hours()/24) && len(windows) < count; i++ {
+		current := time.Date(day.Year(), day.Month(), day.Day()+i, 0, 0, 0, 0, loc)
+		if !r.matchesDay(current) {
+			continue
+		}
+
+		for _, h := range r.hours {
+			for _, m := range r.minutes {
+				start := time.Date(current.Year(), current.Month(), current.Day(), h, m, 0, 0, loc)
+				end := start.Add(duration)
+				if !end.After(after) || start.Before(r.notBefore) || len(windows) >= count {
+					continue
+				}
+				windows = append(windows, recurrenceWindow{Start: start, End: end})
+			}
+		}
+	}
+
+	return windows
+}
@@ -0,0 +1,197 @@
+package myrasec
+
+import (
+	"testing"
+	"time"
+)
+
+// TestRecurrenceNextOccurrences checks the expansion of cron expressions and RRULEs, including the changes of the
+// daylight saving time and the ends of the months
+func TestRecurrenceNextOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("unable to load the time zone: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		recurrence string
+		anchor     string
+		after      string
+		duration   time.Duration
+		count      int
+		loc        *time.Location
+		expected   []string
+	}{
+		{
+			name:       "cron weekly across the start of the daylight saving time",
+			recurrence: "0 3 * * SUN",
+			after:      "2026-03-20T00:00:00Z",
+			duration:   time.Hour,
+			count:      3,
+			loc:        berlin,
+			expected:   []string{"2026-03-22T03:00:00+01:00", "2026-03-29T03:00:00+02:00", "2026-04-05T03:00:00+02:00"},
+		},
+		{
+			name:       "cron daily at a time that is skipped by the daylight saving time",
+			recurrence: "30 2 * * *",
+			after:      "2026-03-28T00:00:00Z",
+			duration:   time.Hour,
+			count:      3,
+			loc:        berlin,
+			expected:   []string{"2026-03-28T02:30:00+01:00", "2026-03-29T03:30:00+02:00", "2026-03-30T02:30:00+02:00"},
+		},
+		{
+			name:       "cron on the 31st skips the shorter months",
+			recurrence: "0 0 31 * *",
+			after:      "2026-01-15T00:00:00Z",
+			duration:   time.Hour,
+			count:      3,
+			loc:        time.UTC,
+			expected:   []string{"2026-01-31T00:00:00Z", "2026-03-31T00:00:00Z", "2026-05-31T00:00:00Z"},
+		},
+		{
+			name:       "cron on the 29th of february",
+			recurrence: "0 0 29 2 *",
+			after:      "2026-01-01T00:00:00Z",
+			duration:   time.Hour,
+			count:      1,
+			loc:        time.UTC,
+			expected:   []string{"2028-02-29T00:00:00Z"},
+		},
+		{
+			name:       "cron with day-of-month or day-of-week",
+			recurrence: "0 12 1 * MON",
+			after:      "2026-06-01T13:00:00Z",
+			duration:   time.Hour,
+			count:      3,
+			loc:        time.UTC,
+			expected:   []string{"2026-06-08T12:00:00Z", "2026-06-15T12:00:00Z", "2026-06-22T12:00:00Z"},
+		},
+		{
+			name:       "cron keeps the window that is active",
+			recurrence: "0 10 * * *",
+			after:      "2026-01-01T11:00:00Z",
+			duration:   2 * time.Hour,
+			count:      2,
+			loc:        time.UTC,
+			expected:   []string{"2026-01-01T10:00:00Z", "2026-01-02T10:00:00Z"},
+		},
+		{
+			name:       "cron does not start before the anchor",
+			recurrence: "0 10 * * *",
+			anchor:     "2026-02-01T00:00:00Z",
+			after:      "2026-01-01T00:00:00Z",
+			duration:   time.Hour,
+			count:      1,
+			loc:        time.UTC,
+			expected:   []string{"2026-02-01T10:00:00Z"},
+		},
+		{
+			name:       "rrule on the last day of the month",
+			recurrence: "RRULE:FREQ=MONTHLY;BYMONTHDAY=-1;BYHOUR=22;BYMINUTE=0",
+			after:      "2026-01-01T00:00:00Z",
+			duration:   time.Hour,
+			count:      3,
+			loc:        time.UTC,
+			expected:   []string{"2026-01-31T22:00:00Z", "2026-02-28T22:00:00Z", "2026-03-31T22:00:00Z"},
+		},
+		{
+			name:       "rrule on the last friday of the month",
+			recurrence: "FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=20;BYMINUTE=0",
+			after:      "2026-01-01T00:00:00Z",
+			duration:   time.Hour,
+			count:      3,
+			loc:        time.UTC,
+			expected:   []string{"2026-01-30T20:00:00Z", "2026-02-27T20:00:00Z", "2026-03-27T20:00:00Z"},
+		},
+		{
+			name:       "rrule on the first monday of the month",
+			recurrence: "FREQ=MONTHLY;BYDAY=1MO;BYHOUR=6;BYMINUTE=0",
+			after:      "2026-01-01T00:00:00Z",
+			duration:   time.Hour,
+			count:      2,
+			loc:        time.UTC,
+			expected:   []string{"2026-01-05T06:00:00Z", "2026-02-02T06:00:00Z"},
+		},
+		{
+			name:       "rrule monthly on the 31st skips the shorter months",
+			recurrence: "FREQ=MONTHLY;BYMONTHDAY=31",
+			anchor:     "2026-01-31T04:00:00Z",
+			after:      "2026-01-01T00:00:00Z",
+			duration:   time.Hour,
+			count:      2,
+			loc:        time.UTC,
+			expected:   []string{"2026-01-31T04:00:00Z", "2026-03-31T04:00:00Z"},
+		},
+		{
+			name:       "rrule every second week",
+			recurrence: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			anchor:     "2026-01-06T10:00:00Z",
+			after:      "2026-01-01T00:00:00Z",
+			duration:   time.Hour,
+			count:      3,
+			loc:        time.UTC,
+			expected:   []string{"2026-01-06T10:00:00Z", "2026-01-20T10:00:00Z", "2026-02-03T10:00:00Z"},
+		},
+		{
+			name:       "rrule daily across the end of the daylight saving time",
+			recurrence: "FREQ=DAILY;BYHOUR=1;BYMINUTE=0",
+			after:      "2026-10-24T00:00:00Z",
+			duration:   4 * time.Hour,
+			count:      3,
+			loc:        berlin,
+			expected:   []string{"2026-10-24T01:00:00+02:00", "2026-10-25T01:00:00+02:00", "2026-10-26T01:00:00+01:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var anchor time.Time
+			if tt.anchor != "" {
+				anchor, _ = time.Parse(time.RFC3339, tt.anchor)
+				anchor = anchor.In(tt.loc)
+			}
+			after, _ := time.Parse(time.RFC3339, tt.after)
+
+			r, err := parseRecurrence(tt.recurrence, anchor)
+			if err != nil {
+				t.Fatalf("unable to parse the recurrence: %v", err)
+			}
+
+			windows := r.nextOccurrences(after, tt.duration, tt.count, tt.loc)
+			if len(windows) != len(tt.expected) {
+				t.Fatalf("expected %d windows, got %v", len(tt.expected), windows)
+			}
+			for i, w := range windows {
+				if start := w.Start.Format(time.RFC3339); start != tt.expected[i] {
+					t.Errorf("expected window %d to start at [%s], got [%s]", i, tt.expected[i], start)
+				}
+				// the duration is elapsed time, also if the window spans a change of the daylight saving time
+				if w.End.Sub(w.Start) != tt.duration {
+					t.Errorf("expected window %d to last %s, got %s", i, tt.duration, w.End.Sub(w.Start))
+				}
+			}
+		})
+	}
+}
+
+// TestRecurrenceInvalid checks that invalid cron expressions and RRULEs are rejected
+func TestRecurrenceInvalid(t *testing.T) {
+	for _, value := range []string{
+		"0 3 * *",
+		"60 3 * * *",
+		"0 3 32 * *",
+		"0 3 * * FOO",
+		"0 5-3 * * *",
+		"FREQ=YEARLY",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=DAILY;INTERVAL=2",
+	} {
+		if _, err := parseRecurrence(value, time.Time{}); err == nil {
+			t.Errorf("expected [%s] to be rejected", value)
+		}
+	}
+}
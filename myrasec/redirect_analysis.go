@@ -0,0 +1,194 @@
+package myrasec
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	redirectFindingShadowed = "shadowed"
+	redirectFindingChain    = "chain"
+	redirectFindingLoop     = "loop"
+)
+
+// redirectFinding is a problem of a redirect that was found by analyzeRedirects
+type redirectFinding struct {
+	Type     string
+	Redirect *myrasec.Redirect
+	Related  *myrasec.Redirect
+	Path     []string
+}
+
+// message returns a description of the finding
+func (f *redirectFinding) message() string {
+	switch f.Type {
+	case redirectFindingShadowed:
+		return fmt.Sprintf("The exact redirect [%s] is never used, because the %s redirect [%s] (sort %d) matches first", f.Redirect.Source, f.Related.MatchingType, f.Related.Source, f.Related.Sort)
+	case redirectFindingLoop:
+		return fmt.Sprintf("Redirect loop: %s", strings.Join(f.Path, " -> "))
+	default:
+		return fmt.Sprintf("Redirect chain: %s. Redirect to the final destination instead", strings.Join(f.Path, " -> "))
+	}
+}
+
+// analyzeRedirects checks the enabled redirects of a subdomain for exact redirects that are shadowed by prefix or suffix redirects,
+// destinations that are redirected again and redirect loops. Loops that contain a redirect with expert_mode are ignored
+func analyzeRedirects(redirects []myrasec.Redirect, subDomainName string) []redirectFinding {
+	var findings []redirectFinding
+
+	ordered := sortRedirects(redirects)
+
+	for i, r := range ordered {
+		if r.MatchingType != "exact" {
+			continue
+		}
+		for _, other := range ordered[:i] {
+			if other.MatchingType != "exact" && matchesRedirect(other, r.Source) {
+				findings = append(findings, redirectFinding{Type: redirectFindingShadowed, Redirect: r, Related: other})
+				break
+			}
+		}
+	}
+
+	for _, r := range ordered {
+		path := []string{r.Source}
+		visited := map[*myrasec.Redirect]bool{r: true}
+		expertMode := r.ExpertMode
+
+		var first *myrasec.Redirect
+		loop := false
+		for current := r; ; {
+			destination, ok := redirectDestinationPath(current.Destination, subDomainName)
+			if !ok {
+				break
+			}
+			next := matchRedirect(ordered, destination)
+			if next == nil {
+				break
+			}
+
+			if first == nil {
+				first = next
+			}
+			path = append(path, destination)
+			expertMode = expertMode || next.ExpertMode
+			if visited[next] {
+				loop = next == r
+				break
+			}
+			visited[next] = true
+			current = next
+		}
+
+		switch {
+		case first == nil:
+		case loop && expertMode:
+		case loop:
+			findings = append(findings, redirectFinding{Type: redirectFindingLoop, Redirect: r, Related: first, Path: path})
+		default:
+			findings = append(findings, redirectFinding{Type: redirectFindingChain, Redirect: r, Related: first, Path: path})
+		}
+	}
+
+	return findings
+}
+
+// sortRedirects returns the enabled redirects in the order they are evaluated (by sort and ID)
+func sortRedirects(redirects []myrasec.Redirect) []*myrasec.Redirect {
+	var ordered []*myrasec.Redirect
+	for i := range redirects {
+		if redirects[i].Enabled {
+			ordered = append(ordered, &redirects[i])
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Sort == ordered[j].Sort {
+			return ordered[i].ID < ordered[j].ID
+		}
+		return ordered[i].Sort < ordered[j].Sort
+	})
+	return ordered
+}
+
+// matchRedirect returns the first redirect whose source matches the passed path
+func matchRedirect(ordered []*myrasec.Redirect, path string) *myrasec.Redirect {
+	for _, r := range ordered {
+		if matchesRedirect(r, path) {
+			return r
+		}
+	}
+	return nil
+}
+
+// matchesRedirect checks if the source of the redirect matches the passed path
+func matchesRedirect(r *myrasec.Redirect, path string) bool {
+	switch r.MatchingType {
+	case "exact":
+		return path == r.Source
+	case "prefix":
+		return strings.HasPrefix(path, r.Source)
+	case "suffix":
+		return strings.HasSuffix(path, r.Source)
+	}
+	return false
+}
+
+// redirectDestinationPath returns the path of the destination if it points to the same subdomain
+func redirectDestinationPath(destination string, subDomainName string) (string, bool) {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return "", false
+	}
+
+	if u.Host != "" && !strings.EqualFold(u.Hostname(), myrasec.RemoveTrailingDot(subDomainName)) {
+		return "", false
+	}
+
+	if u.Path == "" {
+		return "/", true
+	}
+	return u.Path, true
+}
+
+// redirectAnalysisWarnings returns the findings of the analysis of the subdomain that affect the passed redirect as warnings
+func redirectAnalysisWarnings(meta any, redirect *myrasec.Redirect, subDomainName string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	redirects, listDiags := listRedirects(meta, subDomainName, map[string]string{})
+	if listDiags.HasError() {
+		log.Printf("[DEBUG] unable to analyze redirects of [%s]", subDomainName)
+		return diags
+	}
+
+	// the list can be cached, use the current version of the redirect
+	found := false
+	for i := range redirects {
+		if redirects[i].ID == redirect.ID {
+			redirects[i] = *redirect
+			found = true
+		}
+	}
+	if !found {
+		redirects = append(redirects, *redirect)
+	}
+
+	for _, f := range analyzeRedirects(redirects, subDomainName) {
+		if f.Redirect.ID != redirect.ID {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Redirect %s detected", f.Type),
+			Detail:   f.message(),
+		})
+	}
+
+	return diags
+}
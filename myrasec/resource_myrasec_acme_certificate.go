@@ -0,0 +1,753 @@
+package myrasec
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/crypto/acme"
+)
+
+const LetsEncryptDirectoryURL = "https://acme-v02.api.letsencrypt.org/directory"
+
+// acmeCertificatePendingID is the ID of an issued certificate that is not uploaded yet
+const acmeCertificatePendingID = "pending"
+
+// resourceMyrasecACMECertificate ...
+func resourceMyrasecACMECertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecACMECertificateCreate,
+		ReadContext:   resourceMyrasecACMECertificateRead,
+		UpdateContext: resourceMyrasecACMECertificateUpdate,
+		DeleteContext: resourceMyrasecACMECertificateDelete,
+		Schema: map[string]*schema.Schema{
+			"domain_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The domain for the SSL certificate. The domain has to be hosted by Myra DNS to solve the DNS-01 challenges.",
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Stores domain Id for subdomain.",
+			},
+			"dns_names": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(i any) string {
+						return strings.ToLower(myrasec.RemoveTrailingDot(i.(string)))
+					},
+				},
+				Description: "The names the certificate is issued for. The first name is used as common name.",
+			},
+			"subdomains": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(i any) string {
+						return strings.ToLower(myrasec.RemoveTrailingDot(i.(string)))
+					},
+				},
+				Description: "List of subdomains where to assign the certificate",
+			},
+			"directory_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      LetsEncryptDirectoryURL,
+				ValidateFunc: validation.IsURLWithHTTPS,
+				Description:  "The URL of the ACME directory.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Skip the TLS verification of the ACME directory (e.g. for a local test CA).",
+			},
+			"email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Contact email address of the ACME account.",
+			},
+			"account_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Description: "Private key of the ACME account. A new key is generated if none is set.",
+			},
+			"account_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the ACME account.",
+			},
+			"key_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "P256",
+				ValidateFunc: validation.StringInSlice([]string{"P256", "P384", "RSA2048", "RSA4096"}, false),
+				Description:  "Type of the private key of the certificate.",
+			},
+			"renewal_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Renew the certificate when it expires within the given number of days.",
+			},
+			"propagation_wait": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Seconds to wait after creating the DNS-01 challenge records before the challenges are accepted.",
+			},
+			"configuration_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Set specific ssl configuration for ciphers and protocols",
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return newValue == "" || strings.EqualFold(oldValue, newValue)
+				},
+			},
+			"certificate_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the SSL certificate.",
+			},
+			"certificate_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The issued certificate.",
+			},
+			"chain_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The intermediate certificates of the issued certificate.",
+			},
+			"private_key_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The private key of the issued certificate.",
+			},
+			"subject": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Subject of the certificate",
+			},
+			"valid_from": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time the certificate is valid from",
+			},
+			"valid_to": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date and time the certificate is valid to",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CustomizeDiff: resourceCustomizeDiffACMECertificate,
+	}
+}
+
+// resourceCustomizeDiffACMECertificate plans the renewal of the certificate when it expires within the renewal window
+func resourceCustomizeDiffACMECertificate(ctx context.Context, d *schema.ResourceDiff, m any) error {
+	err := validateSSLConfigurationName(d, m)
+	if err != nil {
+		return err
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.Id() == acmeCertificatePendingID {
+		if err := d.SetNewComputed("certificate_id"); err != nil {
+			return err
+		}
+	}
+
+	if !acmeCertificateNeedsRenewal(d.Get("valid_to").(string), d.Get("renewal_days").(int)) {
+		return nil
+	}
+
+	for _, name := range []string{"certificate_id", "certificate_pem", "chain_pem", "private_key_pem", "subject", "valid_from", "valid_to"} {
+		if err := d.SetNewComputed(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceMyrasecACMECertificateCreate ...
+func resourceMyrasecACMECertificateCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainID, diags := findDomainIDByDomainName(d, meta, d.Get("domain_name").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	issued, err := issueACMECertificate(ctx, d, meta, domainID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error issuing ACME certificate",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+	setACMECertificateIssuedData(d, issued)
+
+	// the issued certificate is stored before the upload, so a failed upload does not order a new certificate with the next apply.
+	// An error would taint the resource and replace it, so the failed upload is only reported as warning.
+	d.Set("domain_id", domainID)
+	d.SetId(acmeCertificatePendingID)
+
+	uploadDiags := uploadACMECertificate(ctx, d, meta, domainID)
+	if d.Id() == acmeCertificatePendingID {
+		for i := range uploadDiags {
+			uploadDiags[i].Severity = diag.Warning
+		}
+	}
+	return append(diags, uploadDiags...)
+}
+
+// resourceMyrasecACMECertificateRead ...
+func resourceMyrasecACMECertificateRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if d.Id() == acmeCertificatePendingID {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("ACME certificate [%s] is not uploaded", d.Get("subject").(string)),
+			Detail:   "The issued certificate is stored in the state and is uploaded with the next apply.",
+		})
+		return diags
+	}
+
+	certID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error parsing SSL certificate ID",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	domainID, diags := findDomainIDByDomainName(d, meta, d.Get("domain_name").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	cert, diags := findSSLCertificate(certID, meta, domainID)
+	if diags.HasError() {
+		return diags
+	}
+	if cert == nil {
+		log.Printf("[INFO] SSL certificate [%d] was removed, a new certificate will be issued", certID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("domain_id", domainID)
+	d.Set("certificate_id", cert.ID)
+	d.Set("subdomains", cert.Subdomains)
+	d.Set("configuration_name", cert.SslConfigurationName)
+	d.Set("subject", cert.Subject)
+	if cert.ValidFrom != nil {
+		d.Set("valid_from", cert.ValidFrom.Format(time.RFC3339))
+	}
+	if cert.ValidTo != nil {
+		d.Set("valid_to", cert.ValidTo.Format(time.RFC3339))
+	}
+
+	if acmeCertificateNeedsRenewal(d.Get("valid_to").(string), d.Get("renewal_days").(int)) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("ACME certificate [%s] will be renewed", cert.Subject),
+			Detail:   fmt.Sprintf("The SSL certificate [%d] expires on [%s], which is within the renewal window of %d days.", cert.ID, d.Get("valid_to").(string), d.Get("renewal_days").(int)),
+		})
+	}
+
+	return diags
+}
+
+// resourceMyrasecACMECertificateUpdate renews the certificate or updates the subdomain assignment and the configuration
+func resourceMyrasecACMECertificateUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	if d.Id() == acmeCertificatePendingID {
+		return resourceMyrasecACMECertificateUploadPending(ctx, d, meta)
+	}
+
+	certID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error parsing SSL certificate ID",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	domainID, diags := findDomainIDByDomainName(d, meta, d.Get("domain_name").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	validTo, _ := d.GetChange("valid_to")
+	if acmeCertificateNeedsRenewal(validTo.(string), d.Get("renewal_days").(int)) {
+		log.Printf("[INFO] Renewing ACME certificate: %v", certID)
+
+		issued, err := issueACMECertificate(ctx, d, meta, domainID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error renewing ACME certificate",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		setACMECertificateIssuedData(d, issued)
+
+		cert := buildACMESSLCertificate(d)
+		cert.ID = certID
+		cert.CertRefreshForced = true
+
+		renewed, diags := rotateSSLCertificate(ctx, d, meta, cert, domainID)
+		if renewed == nil {
+			return diags
+		}
+
+		d.SetId(strconv.Itoa(renewed.ID))
+		return append(diags, resourceMyrasecACMECertificateRead(ctx, d, meta)...)
+	}
+
+	log.Printf("[INFO] Updating ACME certificate: %v", certID)
+
+	cert, diags := findSSLCertificate(certID, meta, domainID)
+	if diags.HasError() || cert == nil {
+		return diags
+	}
+
+	update := buildACMESSLCertificate(d)
+	update.ID = cert.ID
+	update.Created = cert.Created
+	update.Modified = cert.Modified
+
+	_, err = client.UpdateSSLCertificate(update, domainID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error updating SSL certificate",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	return resourceMyrasecACMECertificateRead(ctx, d, meta)
+}
+
+// resourceMyrasecACMECertificateDelete ...
+func resourceMyrasecACMECertificateDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	if d.Id() == acmeCertificatePendingID {
+		log.Printf("[INFO] ACME certificate was not uploaded, nothing to delete")
+		return diags
+	}
+
+	certID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error parsing SSL certificate ID",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	log.Printf("[INFO] Deleting ACME certificate: %v", certID)
+
+	domainID, diags := findDomainIDByDomainName(d, meta, d.Get("domain_name").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	cert, diags := findSSLCertificate(certID, meta, domainID)
+	if diags.HasError() || cert == nil {
+		return diags
+	}
+
+	_, err = client.DeleteSSLCertificate(cert, domainID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error deleting SSL certificate",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+	return diags
+}
+
+// resourceMyrasecACMECertificateUploadPending uploads a certificate that was issued, but not uploaded by a previous apply.
+// The certificate is only ordered again, if it expires within the renewal window.
+func resourceMyrasecACMECertificateUploadPending(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainID, diags := findDomainIDByDomainName(d, meta, d.Get("domain_name").(string))
+	if diags.HasError() {
+		return diags
+	}
+
+	validTo, _ := d.GetChange("valid_to")
+	if acmeCertificateNeedsRenewal(validTo.(string), d.Get("renewal_days").(int)) {
+		issued, err := issueACMECertificate(ctx, d, meta, domainID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error renewing ACME certificate",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		setACMECertificateIssuedData(d, issued)
+	}
+
+	log.Printf("[INFO] Uploading pending ACME certificate: %s", d.Get("subject").(string))
+
+	return uploadACMECertificate(ctx, d, meta, domainID)
+}
+
+// uploadACMECertificate uploads the issued certificate from the state and sets the ID of the SSL certificate
+func uploadACMECertificate(ctx context.Context, d *schema.ResourceData, meta any, domainID int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := meta.(*myrasec.API)
+
+	cert := buildACMESSLCertificate(d)
+	resp, err := client.CreateSSLCertificate(cert, domainID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error creating SSL certificate",
+			Detail:   fmt.Sprintf("The issued certificate is stored in the state and the upload is retried with the next apply: %s", formatError(err)),
+		})
+		return diags
+	}
+
+	d.SetId(strconv.Itoa(resp.ID))
+	return resourceMyrasecACMECertificateRead(ctx, d, meta)
+}
+
+// acmeIssuedCertificate contains the result of an ACME order
+type acmeIssuedCertificate struct {
+	certificate   *x509.Certificate
+	chain         []*x509.Certificate
+	privateKeyPEM string
+	accountKeyPEM string
+	accountURL    string
+}
+
+// issueACMECertificate orders a certificate from the ACME directory. The DNS-01 challenges are solved by creating TXT records in Myra DNS,
+// the records are removed after the order is finished.
+func issueACMECertificate(ctx context.Context, d *schema.ResourceData, meta any, domainID int) (*acmeIssuedCertificate, error) {
+	api := meta.(*myrasec.API)
+
+	issued := &acmeIssuedCertificate{}
+
+	accountKey, err := acmeAccountKey(d, issued)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &acme.Client{
+		Key:          accountKey,
+		DirectoryURL: d.Get("directory_url").(string),
+		UserAgent:    "terraform-provider-myrasec",
+	}
+	if d.Get("insecure_skip_verify").(bool) {
+		client.HTTPClient = &http.Client{
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}},
+		}
+	}
+
+	account := &acme.Account{}
+	if email := d.Get("email").(string); email != "" {
+		account.Contact = []string{"mailto:" + email}
+	}
+	account, err = client.Register(ctx, account, acme.AcceptTOS)
+	if errors.Is(err, acme.ErrAccountAlreadyExists) {
+		account, err = client.GetReg(ctx, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to register ACME account: %v", err)
+	}
+	issued.accountURL = account.URI
+
+	names := []string{}
+	for _, name := range d.Get("dns_names").([]any) {
+		names = append(names, strings.ToLower(myrasec.RemoveTrailingDot(name.(string))))
+	}
+
+	order, err := client.AuthorizeOrder(ctx, acme.DomainIDs(names...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ACME order: %v", err)
+	}
+
+	records := []*myrasec.DNSRecord{}
+	defer func() {
+		for _, record := range records {
+			log.Printf("[INFO] Removing DNS-01 challenge record [%s]", record.Name)
+			if _, err := api.DeleteDNSRecord(record, domainID); err != nil {
+				log.Printf("[WARN] Unable to remove DNS-01 challenge record [%s]: %v", record.Name, err)
+			}
+		}
+	}()
+
+	challenges := []*acme.Challenge{}
+	authorizations := []string{}
+	for _, url := range order.AuthzURLs {
+		authz, err := client.GetAuthorization(ctx, url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch ACME authorization: %v", err)
+		}
+		if authz.Status == acme.StatusValid {
+			continue
+		}
+
+		var challenge *acme.Challenge
+		for _, c := range authz.Challenges {
+			if c.Type == "dns-01" {
+				challenge = c
+				break
+			}
+		}
+		if challenge == nil {
+			return nil, fmt.Errorf("the ACME directory does not offer a dns-01 challenge for [%s]", authz.Identifier.Value)
+		}
+
+		value, err := client.DNS01ChallengeRecord(challenge.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := api.CreateDNSRecord(&myrasec.DNSRecord{
+			Name:       "_acme-challenge." + authz.Identifier.Value,
+			Value:      value,
+			RecordType: "TXT",
+			TTL:        300,
+			Enabled:    true,
+			Comment:    "ACME DNS-01 challenge (terraform)",
+		}, domainID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DNS-01 challenge record for [%s]: %v", authz.Identifier.Value, err)
+		}
+		records = append(records, record)
+
+		challenges = append(challenges, challenge)
+		authorizations = append(authorizations, authz.URI)
+	}
+
+	if len(challenges) > 0 {
+		select {
+		case <-time.After(time.Duration(d.Get("propagation_wait").(int)) * time.Second):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	for i, challenge := range challenges {
+		if _, err := client.Accept(ctx, challenge); err != nil {
+			return nil, fmt.Errorf("failed to accept ACME challenge: %v", err)
+		}
+		if _, err := client.WaitAuthorization(ctx, authorizations[i]); err != nil {
+			return nil, fmt.Errorf("ACME authorization failed: %v", err)
+		}
+	}
+
+	order, err = client.WaitOrder(ctx, order.URI)
+	if err != nil {
+		return nil, fmt.Errorf("ACME order failed: %v", err)
+	}
+
+	key, err := generateACMECertificateKey(d.Get("key_type").(string))
+	if err != nil {
+		return nil, err
+	}
+	issued.privateKeyPEM, err = encodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: names[0]},
+		DNSNames: names,
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+
+	ders, _, err := client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize ACME order: %v", err)
+	}
+
+	for i, der := range ders {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			issued.certificate = cert
+		} else {
+			issued.chain = append(issued.chain, cert)
+		}
+	}
+	if issued.certificate == nil {
+		return nil, errors.New("the ACME directory did not return a certificate")
+	}
+
+	return issued, nil
+}
+
+// acmeAccountKey returns the configured ACME account key or generates a new one
+func acmeAccountKey(d *schema.ResourceData, issued *acmeIssuedCertificate) (crypto.Signer, error) {
+	if keyPEM := d.Get("account_key_pem").(string); keyPEM != "" {
+		key, err := parsePrivateKey(keyPEM, "")
+		if err != nil {
+			return nil, fmt.Errorf("account_key_pem: %v", err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("account_key_pem: unsupported private key type")
+		}
+		issued.accountKeyPEM = keyPEM
+		return signer, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	issued.accountKeyPEM, err = encodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// generateACMECertificateKey generates the private key of the certificate
+func generateACMECertificateKey(keyType string) (crypto.Signer, error) {
+	switch keyType {
+	case "P384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "RSA2048":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "RSA4096":
+		return rsa.GenerateKey(rand.Reader, 4096)
+	}
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+// acmeCertificateNeedsRenewal checks if the certificate expires within the renewal window
+func acmeCertificateNeedsRenewal(validTo string, renewalDays int) bool {
+	if validTo == "" {
+		return false
+	}
+
+	t, err := time.Parse(time.RFC3339, validTo)
+	if err != nil {
+		return false
+	}
+
+	return time.Now().AddDate(0, 0, renewalDays).After(t)
+}
+
+// setACMECertificateIssuedData stores the issued certificate, the chain and the keys
+func setACMECertificateIssuedData(d *schema.ResourceData, issued *acmeIssuedCertificate) {
+	chain := ""
+	for _, c := range issued.chain {
+		chain += encodePEMCertificate(c)
+	}
+
+	d.Set("account_key_pem", issued.accountKeyPEM)
+	d.Set("account_url", issued.accountURL)
+	d.Set("certificate_pem", encodePEMCertificate(issued.certificate))
+	d.Set("chain_pem", chain)
+	d.Set("private_key_pem", issued.privateKeyPEM)
+	d.Set("subject", issued.certificate.Subject.String())
+	d.Set("valid_from", issued.certificate.NotBefore.Format(time.RFC3339))
+	d.Set("valid_to", issued.certificate.NotAfter.Format(time.RFC3339))
+}
+
+// buildACMESSLCertificate builds the SSL certificate from the issued certificate
+func buildACMESSLCertificate(d *schema.ResourceData) *myrasec.SSLCertificate {
+	cert := &myrasec.SSLCertificate{
+		Certificate: &myrasec.Certificate{
+			Cert: d.Get("certificate_pem").(string),
+		},
+		Key:        d.Get("private_key_pem").(string),
+		Subdomains: []string{},
+	}
+
+	for _, sd := range d.Get("subdomains").(*schema.Set).List() {
+		cert.Subdomains = append(cert.Subdomains, sd.(string))
+	}
+
+	configurationName, ok := d.GetOk("configuration_name")
+	if ok {
+		cert.SslConfigurationName = configurationName.(string)
+	}
+
+	if chain, err := parsePEMCertificates(d.Get("chain_pem").(string)); err == nil {
+		for _, c := range chain {
+			cert.Intermediates = append(cert.Intermediates, myrasec.SSLIntermediate{
+				Certificate: &myrasec.Certificate{
+					Cert: encodePEMCertificate(c),
+				},
+			})
+		}
+	}
+
+	return cert
+}
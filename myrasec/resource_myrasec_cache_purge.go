@@ -0,0 +1,198 @@
+package myrasec
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceMyrasecCachePurge ...
+func resourceMyrasecCachePurge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecCachePurgeCreate,
+		ReadContext:   resourceMyrasecCachePurgeRead,
+		DeleteContext: resourceMyrasecCachePurgeDelete,
+		Schema: map[string]*schema.Schema{
+			"subdomain_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return myrasec.RemoveTrailingDot(old) == myrasec.RemoveTrailingDot(new)
+				},
+				ExactlyOneOf: []string{"subdomain_name", "fqdn"},
+				Description:  "The Subdomain to purge the cache for.",
+			},
+			"fqdn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subdomain_name", "fqdn"},
+				RequiredWith: []string{"domain_name"},
+				Description:  "FQDN pattern to purge the cache for, e.g. *.example.com.",
+			},
+			"domain_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return myrasec.RemoveTrailingDot(old) == myrasec.RemoveTrailingDot(new)
+				},
+				Description: "The Domain of the FQDN pattern.",
+			},
+			"paths": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				Description: "Patterns of the resources to purge, e.g. /index.html or *.css. Purges all resources if not set.",
+			},
+			"recursive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Purge the matching resources in all subfolders.",
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary values that trigger a new purge when they change.",
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Stores domain ID of the subdomain.",
+			},
+			"purged_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date of the purge.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+// resourceMyrasecCachePurgeCreate ...
+func resourceMyrasecCachePurgeCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var domainID int
+	var fqdn string
+
+	if _, ok := d.GetOk("subdomain_name"); ok {
+		domainID, fqdn, diags = findSubdomainNameAndDomainID(d, meta)
+	} else {
+		fqdn = d.Get("fqdn").(string)
+		domainID, diags = findDomainIDByDomainName(d, meta, myrasec.RemoveTrailingDot(d.Get("domain_name").(string)))
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	var paths []string
+	for _, p := range d.Get("paths").([]any) {
+		paths = append(paths, p.(string))
+	}
+
+	now := time.Now()
+	err := purgeCache(meta, domainID, myrasec.RemoveTrailingDot(fqdn), paths, d.Get("recursive").(bool))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error purging cache",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", myrasec.RemoveTrailingDot(fqdn), now.Unix()))
+	d.Set("domain_id", domainID)
+	d.Set("purged_at", now.Format(time.RFC3339))
+
+	return diags
+}
+
+// resourceMyrasecCachePurgeRead ...
+func resourceMyrasecCachePurgeRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	// a purge is an action, there is nothing to read
+	return nil
+}
+
+// resourceMyrasecCachePurgeDelete ...
+func resourceMyrasecCachePurgeDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	// a purge cannot be undone, the resource is only removed from the state
+	return nil
+}
+
+// purgeCache purges the cache of the passed FQDN for every path. All resources are purged if no path is passed
+func purgeCache(meta any, domainID int, fqdn string, paths []string, recursive bool) error {
+	client := meta.(*myrasec.API)
+
+	if len(paths) == 0 {
+		paths = []string{"*"}
+	}
+
+	for _, path := range paths {
+		log.Printf("[INFO] Purging cache: %s %s", fqdn, path)
+		_, err := client.ClearCache(&myrasec.CacheClear{
+			FQDN:      fqdn,
+			Resource:  path,
+			Recursive: recursive,
+		}, domainID)
+		if err != nil {
+			return fmt.Errorf("[%s %s]: %s", fqdn, path, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// purgeOnChangeSchema returns the schema of the purge_on_change attribute
+func purgeOnChangeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Purge the affected resources from the cache when this resource is created or changed.",
+	}
+}
+
+// purgeOnChange purges the cache if purge_on_change is set. A failed purge is returned as warning
+func purgeOnChange(d *schema.ResourceData, meta any, domainID int, fqdn string, path string, recursive bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !d.Get("purge_on_change").(bool) {
+		return diags
+	}
+
+	// enabling purge_on_change alone does not purge the cache
+	if !d.IsNewResource() && !d.HasChangeExcept("purge_on_change") {
+		return diags
+	}
+
+	err := purgeCache(meta, domainID, myrasec.RemoveTrailingDot(fqdn), []string{path}, recursive)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Error purging cache",
+			Detail:   formatError(err),
+		})
+	}
+	return diags
+}
@@ -0,0 +1,454 @@
+package myrasec
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceMyrasecCacheRuleSet ...
+func resourceMyrasecCacheRuleSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecCacheRuleSetCreate,
+		ReadContext:   resourceMyrasecCacheRuleSetRead,
+		UpdateContext: resourceMyrasecCacheRuleSetUpdate,
+		DeleteContext: resourceMyrasecCacheRuleSetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMyrasecCacheRuleSetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"subdomain_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					name := i.(string)
+					if myrasec.IsGeneralDomainName(name) {
+						return name
+					}
+					return strings.ToLower(name)
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return myrasec.RemoveTrailingDot(old) == myrasec.RemoveTrailingDot(new)
+				},
+				ExactlyOneOf: []string{"subdomain_name", "tag_id"},
+				Description:  "The Subdomain for the cache settings.",
+			},
+			"tag_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"subdomain_name", "tag_id"},
+				Description:  "The Id of the CACHE tag for the cache settings.",
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Stores domain ID of the subdomain.",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ordered list of cache settings. The first matching rule takes action.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"exact", "prefix", "suffix"}, false),
+							Description:  "Type how path should match.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Path which must match to cache response.",
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Time to live.",
+						},
+						"not_found_ttl": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "How long an object will be cached. Origin responses with the HTTP codes 404 will be cached.",
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Define wether this cache setting is enabled or not.",
+						},
+						"enforce": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Enforce cache TTL allows you to set the cache TTL (Cache Control: max-age) in the backend regardless of the response sent from your Origin.",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "A comment to describe this cache setting.",
+						},
+					},
+				},
+			},
+			"setting_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "IDs of the cache settings by type and path.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		CustomizeDiff: resourceCustomizeDiffCacheRuleSet,
+	}
+}
+
+// resourceCustomizeDiffCacheRuleSet checks for duplicate rules
+func resourceCustomizeDiffCacheRuleSet(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if !d.NewValueKnown("rule") {
+		return d.SetNewComputed("setting_ids")
+	}
+
+	keys := map[string]int{}
+	for i, r := range d.Get("rule").([]any) {
+		if r == nil {
+			continue
+		}
+		rule := r.(map[string]any)
+		key := cacheRuleKey(rule["type"].(string), rule["path"].(string))
+		if j, ok := keys[key]; ok {
+			return fmt.Errorf("duplicate cache rule [%s] (%s) in rule #%d and #%d", rule["path"], rule["type"], j+1, i+1)
+		}
+		keys[key] = i
+	}
+
+	if d.HasChange("rule") {
+		return d.SetNewComputed("setting_ids")
+	}
+	return nil
+}
+
+// resourceMyrasecCacheRuleSetCreate ...
+func resourceMyrasecCacheRuleSetCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	if tagID, ok := d.GetOk("tag_id"); ok {
+		tag, diags := getTag(tagID.(int), meta)
+		if diags.HasError() {
+			return diags
+		}
+		if tag == nil || tag.Type != "CACHE" {
+			return diag.Errorf("tag [%d] is not a CACHE tag", tagID.(int))
+		}
+		d.SetId(fmt.Sprintf("tag:%d", tagID.(int)))
+	} else {
+		d.SetId(myrasec.RemoveTrailingDot(d.Get("subdomain_name").(string)))
+	}
+
+	diags := syncCacheRuleSet(d, meta)
+	return append(diags, resourceMyrasecCacheRuleSetRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecCacheRuleSetRead ...
+func resourceMyrasecCacheRuleSetRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	settings, diags := listCacheRuleSetSettings(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	rules := []any{}
+	ids := map[string]any{}
+	for _, s := range settings {
+		rules = append(rules, map[string]any{
+			"type":          s.Type,
+			"path":          s.Path,
+			"ttl":           s.TTL,
+			"not_found_ttl": s.NotFoundTTL,
+			"enabled":       s.Enabled,
+			"enforce":       s.Enforce,
+			"comment":       s.Comment,
+		})
+		ids[cacheRuleKey(s.Type, s.Path)] = s.ID
+	}
+	d.Set("rule", rules)
+	d.Set("setting_ids", ids)
+
+	for _, u := range findUnreachableCacheRules(settings) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Cache rule can never match",
+			Detail:   fmt.Sprintf("The %s cache rule [%s] can never match, because the %s cache rule [%s] before it matches all of its paths", u.rule.Type, u.rule.Path, u.by.Type, u.by.Path),
+		})
+	}
+
+	return diags
+}
+
+// resourceMyrasecCacheRuleSetUpdate ...
+func resourceMyrasecCacheRuleSetUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[INFO] Updating cache rule set: %v", d.Id())
+
+	diags := syncCacheRuleSet(d, meta)
+	return append(diags, resourceMyrasecCacheRuleSetRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecCacheRuleSetDelete ...
+func resourceMyrasecCacheRuleSetDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[INFO] Deleting cache rule set: %v", d.Id())
+
+	settings, diags := listCacheRuleSetSettings(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	managed := map[int]bool{}
+	for _, id := range d.Get("setting_ids").(map[string]any) {
+		managed[id.(int)] = true
+	}
+
+	for i := range settings {
+		if !managed[settings[i].ID] {
+			continue
+		}
+		if err := deleteCacheRuleSetSetting(d, meta, &settings[i]); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting cache setting",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// resourceMyrasecCacheRuleSetImport imports the cache settings of a subdomain (www.example.com) or of a CACHE tag (tag:0000)
+func resourceMyrasecCacheRuleSetImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	if tag, ok := strings.CutPrefix(d.Id(), "tag:"); ok {
+		tagID, err := strconv.Atoi(tag)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert tagID to int")
+		}
+		d.Set("tag_id", tagID)
+	} else {
+		d.Set("subdomain_name", d.Id())
+	}
+
+	diags := resourceMyrasecCacheRuleSetRead(ctx, d, meta)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unable to load cache settings for [%s]", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// syncCacheRuleSet creates, updates and deletes the cache settings to match the ordered list of rules.
+// Cache settings that are not part of the list are deleted
+func syncCacheRuleSet(d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+	// the reads during the sync may still hit the cache, prune it so the Read that follows sees the changes
+	defer client.PruneCache()
+
+	settings, diags := listCacheRuleSetSettings(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	var desired []*myrasec.CacheSetting
+	keys := map[string]bool{}
+	for i, r := range d.Get("rule").([]any) {
+		rule := r.(map[string]any)
+		setting := &myrasec.CacheSetting{
+			Type:        rule["type"].(string),
+			Path:        rule["path"].(string),
+			TTL:         rule["ttl"].(int),
+			NotFoundTTL: rule["not_found_ttl"].(int),
+			Sort:        i,
+			Enabled:     rule["enabled"].(bool),
+			Enforce:     rule["enforce"].(bool),
+			Comment:     rule["comment"].(string),
+		}
+		desired = append(desired, setting)
+		keys[cacheRuleKey(setting.Type, setting.Path)] = true
+	}
+
+	// existing settings are reused by type and path, all other settings are deleted
+	existing := map[string]*myrasec.CacheSetting{}
+	for i := range settings {
+		s := &settings[i]
+		key := cacheRuleKey(s.Type, s.Path)
+		if _, ok := existing[key]; !ok && keys[key] {
+			existing[key] = s
+			continue
+		}
+
+		log.Printf("[INFO] Deleting cache setting: %v", s.ID)
+		if err := deleteCacheRuleSetSetting(d, meta, s); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting cache setting",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	for _, setting := range desired {
+		current, ok := existing[cacheRuleKey(setting.Type, setting.Path)]
+		if !ok {
+			if err := saveCacheRuleSetSetting(d, meta, setting); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error creating cache setting",
+					Detail:   formatError(fmt.Errorf("[%s]: %s", setting.Path, err.Error())),
+				})
+				return diags
+			}
+			continue
+		}
+
+		if current.TTL == setting.TTL && current.NotFoundTTL == setting.NotFoundTTL && current.Sort == setting.Sort &&
+			current.Enabled == setting.Enabled && current.Enforce == setting.Enforce && current.Comment == setting.Comment {
+			continue
+		}
+
+		setting.ID = current.ID
+		setting.Created = current.Created
+		setting.Modified = current.Modified
+		if err := saveCacheRuleSetSetting(d, meta, setting); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error updating cache setting",
+				Detail:   formatError(fmt.Errorf("[%s]: %s", setting.Path, err.Error())),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// listCacheRuleSetSettings returns the cache settings of the subdomain or the tag ordered by sort
+func listCacheRuleSetSettings(d *schema.ResourceData, meta any) ([]myrasec.CacheSetting, diag.Diagnostics) {
+	var settings []myrasec.CacheSetting
+	var diags diag.Diagnostics
+
+	if tagID, ok := d.GetOk("tag_id"); ok {
+		settings, diags = listTagCacheSettings(tagID.(int), meta, map[string]string{})
+	} else {
+		var domainID int
+		var subDomainName string
+		domainID, subDomainName, diags = findSubdomainNameAndDomainID(d, meta)
+		if diags.HasError() {
+			return nil, diags
+		}
+		d.Set("domain_id", domainID)
+		settings, diags = listCacheSettings(meta, subDomainName, map[string]string{})
+	}
+
+	sort.SliceStable(settings, func(i, j int) bool {
+		if settings[i].Sort == settings[j].Sort {
+			return settings[i].ID < settings[j].ID
+		}
+		return settings[i].Sort < settings[j].Sort
+	})
+
+	return settings, diags
+}
+
+// saveCacheRuleSetSetting creates or updates the passed cache setting of the subdomain or the tag
+func saveCacheRuleSetSetting(d *schema.ResourceData, meta any, setting *myrasec.CacheSetting) error {
+	client := meta.(*myrasec.API)
+
+	var err error
+	if tagID, ok := d.GetOk("tag_id"); ok {
+		if setting.ID > 0 {
+			_, err = client.UpdateTagCacheSetting(setting, tagID.(int))
+		} else {
+			_, err = client.CreateTagCacheSetting(setting, tagID.(int))
+		}
+		return err
+	}
+
+	subDomainName := d.Get("subdomain_name").(string)
+	domainID := d.Get("domain_id").(int)
+	if setting.ID > 0 {
+		_, err = client.UpdateCacheSetting(setting, domainID, subDomainName)
+	} else {
+		_, err = client.CreateCacheSetting(setting, domainID, subDomainName)
+	}
+	return err
+}
+
+// deleteCacheRuleSetSetting deletes the passed cache setting of the subdomain or the tag
+func deleteCacheRuleSetSetting(d *schema.ResourceData, meta any, setting *myrasec.CacheSetting) error {
+	client := meta.(*myrasec.API)
+
+	var err error
+	if tagID, ok := d.GetOk("tag_id"); ok {
+		_, err = client.DeleteTagCacheSetting(setting, tagID.(int))
+	} else {
+		_, err = client.DeleteCacheSetting(setting, d.Get("domain_id").(int), d.Get("subdomain_name").(string))
+	}
+	return err
+}
+
+// cacheRuleKey identifies a cache setting by type and path
+func cacheRuleKey(settingType string, path string) string {
+	return settingType + ":" + path
+}
+
+// unreachableCacheRule is a cache setting that is shadowed by an earlier cache setting
+type unreachableCacheRule struct {
+	rule *myrasec.CacheSetting
+	by   *myrasec.CacheSetting
+}
+
+// findUnreachableCacheRules returns the enabled cache settings that can never match, because an earlier enabled cache setting matches all of their paths.
+// The settings must be ordered by sort
+func findUnreachableCacheRules(settings []myrasec.CacheSetting) []unreachableCacheRule {
+	var result []unreachableCacheRule
+
+	for j := range settings {
+		if !settings[j].Enabled {
+			continue
+		}
+		for i := range settings[:j] {
+			if settings[i].Enabled && coversCacheRule(&settings[i], &settings[j]) {
+				result = append(result, unreachableCacheRule{rule: &settings[j], by: &settings[i]})
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// coversCacheRule checks if every path that matches the rule also matches the other rule
+func coversCacheRule(other *myrasec.CacheSetting, rule *myrasec.CacheSetting) bool {
+	switch other.Type {
+	case "exact":
+		return rule.Type == "exact" && rule.Path == other.Path
+	case "prefix":
+		return (rule.Type == "exact" || rule.Type == "prefix") && strings.HasPrefix(rule.Path, other.Path)
+	case "suffix":
+		return (rule.Type == "exact" || rule.Type == "suffix") && strings.HasSuffix(rule.Path, other.Path)
+	}
+	return false
+}
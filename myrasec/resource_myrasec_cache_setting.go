@@ -107,6 +107,7 @@ func resourceMyrasecCacheSetting() *schema.Resource {
 				Computed:    true,
 				Description: "Stores domain ID of the subdomain.",
 			},
+			"purge_on_change": purgeOnChangeSchema(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Second),
@@ -139,7 +140,8 @@ func resourceMyrasecCacheSettingCreate(ctx context.Context, d *schema.ResourceDa
 	resp, err := client.CreateCacheSetting(setting, domainID, subDomainName)
 	if err == nil {
 		setCacheSettingData(d, resp, subDomainName, domainID)
-		return diags
+		path, recursive := cacheSettingPurgePath(resp)
+		return append(diags, purgeOnChange(d, meta, domainID, subDomainName, path, recursive)...)
 	}
 
 	setting, errImport := importExistingCacheSetting(setting, domainID, subDomainName, meta)
@@ -238,6 +240,17 @@ func resourceMyrasecCacheSettingUpdate(ctx context.Context, d *schema.ResourceDa
 
 	setCacheSettingData(d, setting, subDomainName, domainID)
 
+	path, recursive := cacheSettingPurgePath(setting)
+	diags = append(diags, purgeOnChange(d, meta, domainID, subDomainName, path, recursive)...)
+
+	// the paths that matched the previous cache setting are cached with the previous settings
+	if d.HasChanges("path", "type") {
+		oldPath, _ := d.GetChange("path")
+		oldType, _ := d.GetChange("type")
+		path, recursive = cacheSettingPurgePath(&myrasec.CacheSetting{Path: oldPath.(string), Type: oldType.(string)})
+		diags = append(diags, purgeOnChange(d, meta, domainID, subDomainName, path, recursive)...)
+	}
+
 	return diags
 }
 
@@ -413,6 +426,17 @@ func setCacheSettingData(d *schema.ResourceData, setting *myrasec.CacheSetting,
 	d.Set("domain_id", domainID)
 }
 
+// cacheSettingPurgePath returns the resource pattern to purge the paths that match the cache setting
+func cacheSettingPurgePath(setting *myrasec.CacheSetting) (string, bool) {
+	switch setting.Type {
+	case "prefix":
+		return setting.Path + "*", true
+	case "suffix":
+		return "*" + setting.Path, true
+	}
+	return setting.Path, false
+}
+
 // importExistingCacheSetting ...
 func importExistingCacheSetting(setting *myrasec.CacheSetting, domainId int, subDomainName string, meta any) (*myrasec.CacheSetting, error) {
 	client := meta.(*myrasec.API)
@@ -60,15 +60,12 @@ func resourceMyrasecErrorPage() *schema.Resource {
 				ForceNew:     true,
 			},
 			"content": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "HTML content of the error page.",
-				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-					oldHash := d.Get("content_hash")
-					newHash := createContentHash(newValue)
-					return oldHash == newHash
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "HTML content of the error page.",
+				DiffSuppressFunc: suppressRenderedContentDiff,
 			},
+			"content_source_dir": htmlContentSourceDirSchema(),
 			"content_hash": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -78,11 +75,13 @@ func resourceMyrasecErrorPage() *schema.Resource {
 				Computed:    true,
 				Description: "Stores domain ID of the subdomain.",
 			},
+			"purge_on_change": purgeOnChangeSchema(),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Second),
 			Update: schema.DefaultTimeout(30 * time.Second),
 		},
+		CustomizeDiff: resourceCustomizeDiffHTMLContent,
 	}
 }
 
@@ -118,7 +117,8 @@ func resourceMyrasecErrorPageCreate(ctx context.Context, d *schema.ResourceData,
 	}
 	client.PruneCache()
 
-	return resourceMyrasecErrorPageRead(ctx, d, meta)
+	diags = purgeOnChange(d, meta, domainID, d.Get("subdomain_name").(string), "*", true)
+	return append(diags, resourceMyrasecErrorPageRead(ctx, d, meta)...)
 }
 
 // resourceMyrasecErrorPageRead ...
@@ -181,7 +181,7 @@ func resourceMyrasecErrorPageUpdate(ctx context.Context, d *schema.ResourceData,
 
 	setErrorPageData(d, errorPage, domainID)
 
-	return diags
+	return append(diags, purgeOnChange(d, meta, domainID, d.Get("subdomain_name").(string), "*", true)...)
 }
 
 // resourceMyrasecErrorPageDelete ...
@@ -274,8 +274,13 @@ func resourceMyrasecErrorPageImport(ctx context.Context, d *schema.ResourceData,
 // buildErrorPage ...
 func buildErrorPage(d *schema.ResourceData) (*myrasec.ErrorPage, error) {
 
+	content, err := renderHTMLContent(d.Get("content").(string), d.Get("content_source_dir").(string))
+	if err != nil {
+		return nil, err
+	}
+
 	errorPage := &myrasec.ErrorPage{
-		Content:       d.Get("content").(string),
+		Content:       content,
 		ErrorCode:     d.Get("error_code").(int),
 		SubDomainName: d.Get("subdomain_name").(string),
 	}
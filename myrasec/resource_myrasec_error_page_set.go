@@ -0,0 +1,510 @@
+package myrasec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// errorPageTemplateData is passed to the template of an error page set
+type errorPageTemplateData struct {
+	ErrorCode int
+	Vars      map[string]string
+}
+
+// resourceMyrasecErrorPageSet ...
+func resourceMyrasecErrorPageSet() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecErrorPageSetCreate,
+		ReadContext:   resourceMyrasecErrorPageSetRead,
+		UpdateContext: resourceMyrasecErrorPageSetUpdate,
+		DeleteContext: resourceMyrasecErrorPageSetDelete,
+		Schema: map[string]*schema.Schema{
+			"subdomain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					name := i.(string)
+					if myrasec.IsGeneralDomainName(name) {
+						return name
+					}
+					return myrasec.RemoveTrailingDot(strings.ToLower(name))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return myrasec.RemoveTrailingDot(old) == myrasec.RemoveTrailingDot(new)
+				},
+				Description: "The Subdomain for the error pages.",
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Stores domain ID of the subdomain.",
+			},
+			"template": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Go template that is rendered for every error code.",
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					return d.Get("template_hash") == createContentHash(newValue)
+				},
+			},
+			"template_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"template_engine": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "html",
+				ValidateFunc: validation.StringInSlice([]string{"html", "text"}, false),
+				Description:  "The template package used to render the pages. `html` escapes the variables, `text` does not.",
+			},
+			"variables": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Variables that are available for all error codes.",
+			},
+			"error_codes": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntInSlice([]int{400, 405, 429, 500, 502, 503, 504, 9999}),
+				},
+				Description: "Error codes to render an error page for.",
+			},
+			"code_variables": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"error_code": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The error code the variables are used for.",
+						},
+						"variables": {
+							Type:     schema.TypeMap,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "Variables for the error code. They overwrite the variables with the same name.",
+						},
+					},
+				},
+				Description: "Variables for single error codes.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Adopt existing error pages of the subdomain for the error codes. Otherwise these error pages are reported as conflict.",
+			},
+			"content_hashes": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Hash of the content of every error page.",
+			},
+		},
+		CustomizeDiff: resourceCustomizeDiffErrorPageSet,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Second),
+			Update: schema.DefaultTimeout(60 * time.Second),
+		},
+	}
+}
+
+// resourceCustomizeDiffErrorPageSet renders the error pages and plans an update when the content of a page differs
+func resourceCustomizeDiffErrorPageSet(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	tmpl, known := rawConfigString(d.GetRawConfig(), "template")
+	if !known || !d.NewValueKnown("variables") || !d.NewValueKnown("error_codes") || !d.NewValueKnown("code_variables") {
+		return d.SetNewComputed("content_hashes")
+	}
+
+	pages, err := renderErrorPageSet(tmpl, d.Get("template_engine").(string), d.Get("variables").(map[string]any), d.Get("error_codes").(*schema.Set), d.Get("code_variables").([]any))
+	if err != nil {
+		return err
+	}
+
+	hashes := map[string]any{}
+	for code, content := range pages {
+		hashes[strconv.Itoa(code)] = createContentHash(content)
+	}
+
+	current := d.Get("content_hashes").(map[string]any)
+	changed := len(current) != len(hashes)
+	for code, hash := range hashes {
+		if current[code] != hash {
+			changed = true
+		}
+	}
+
+	if changed {
+		return d.SetNew("content_hashes", hashes)
+	}
+	return nil
+}
+
+// resourceMyrasecErrorPageSetCreate ...
+func resourceMyrasecErrorPageSetCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainID, diags := findDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the ID is set before the upload, so the error pages that are created are kept in the state also if an error occurs
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	diags = append(diags, syncErrorPageSet(d, meta, domainID)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecErrorPageSetRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecErrorPageSetRead ...
+func resourceMyrasecErrorPageSetRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the managed error pages are the ones of the error codes and the ones that were uploaded before
+	codes := map[int]bool{}
+	for _, code := range d.Get("error_codes").(*schema.Set).List() {
+		codes[code.(int)] = true
+	}
+	for code := range d.Get("content_hashes").(map[string]any) {
+		errorCode, _ := strconv.Atoi(code)
+		codes[errorCode] = true
+	}
+
+	remote, diags := listErrorPageSetPages(meta, domainID, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	hashes := map[string]any{}
+	for code, ep := range remote {
+		if !codes[code] {
+			continue
+		}
+
+		epx, err := client.GetErrorPage(domainID, ep.ID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error loading error page content",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		hashes[strconv.Itoa(code)] = createContentHash(epx.Content)
+	}
+
+	d.Set("template", "")
+	d.Set("content_hashes", hashes)
+	d.Set("domain_id", domainID)
+
+	return diags
+}
+
+// resourceMyrasecErrorPageSetUpdate ...
+func resourceMyrasecErrorPageSetUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainID, diags := findDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	diags = append(diags, syncErrorPageSet(d, meta, domainID)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecErrorPageSetRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecErrorPageSetDelete ...
+func resourceMyrasecErrorPageSetDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	domainID, diags := findDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	// only the error pages that were created or adopted by this resource are deleted
+	for code := range d.Get("content_hashes").(map[string]any) {
+		log.Printf("[INFO] Deleting error page: %v", code)
+
+		errorCode, _ := strconv.Atoi(code)
+		_, err := client.DeleteErrorPage(&myrasec.ErrorPage{
+			ErrorCode:     errorCode,
+			SubDomainName: d.Get("subdomain_name").(string),
+		}, domainID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting error page",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// syncErrorPageSet uploads the rendered error pages that changed and deletes the error pages of removed error codes
+func syncErrorPageSet(d *schema.ResourceData, meta any, domainID int) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+	// the reads during the sync may still hit the cache, prune it so the Read that follows sees the changes
+	defer client.PruneCache()
+
+	var diags diag.Diagnostics
+
+	tmpl, _ := rawConfigString(d.GetRawConfig(), "template")
+	pages, err := renderErrorPageSet(tmpl, d.Get("template_engine").(string), d.Get("variables").(map[string]any), d.Get("error_codes").(*schema.Set), d.Get("code_variables").([]any))
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error rendering error pages",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	subDomainName := d.Get("subdomain_name").(string)
+	remote, diags := listErrorPageSetPages(meta, domainID, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	// the error pages that are managed by this resource and still exist
+	o, _ := d.GetChange("content_hashes")
+	current := map[string]any{}
+	for code, hash := range o.(map[string]any) {
+		errorCode, _ := strconv.Atoi(code)
+		if _, ok := remote[errorCode]; ok {
+			current[code] = hash
+		}
+	}
+
+	codes := []int{}
+	for code := range pages {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	// error pages of the subdomain that are not managed yet are only adopted if adopt_existing is set,
+	// they may be managed by a myrasec_error_page resource or in the Myra UI
+	if !d.Get("adopt_existing").(bool) {
+		conflicts := []string{}
+		for _, code := range codes {
+			if _, ok := current[strconv.Itoa(code)]; ok {
+				continue
+			}
+			if ep, ok := remote[code]; ok {
+				conflicts = append(conflicts, fmt.Sprintf("%d (ID = %d)", code, ep.ID))
+			}
+		}
+		if len(conflicts) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error pages already exist",
+				Detail:   formatError(fmt.Errorf("the error pages [%s] of [%s] already exist and are not managed by this error page set. Remove them or set adopt_existing = true to manage them with this error page set", strings.Join(conflicts, ", "), subDomainName)),
+			})
+			return diags
+		}
+	}
+
+	// keep track of the managed error pages, also if an error occurs
+	defer func() {
+		d.Set("content_hashes", current)
+		d.Set("domain_id", domainID)
+	}()
+
+	for _, code := range codes {
+		hash := createContentHash(pages[code])
+		if current[strconv.Itoa(code)] == hash {
+			continue
+		}
+
+		errorPage := &myrasec.ErrorPage{
+			ErrorCode:     code,
+			Content:       pages[code],
+			SubDomainName: subDomainName,
+		}
+
+		if _, ok := remote[code]; ok {
+			_, err = client.UpdateErrorPage(errorPage, domainID)
+		} else {
+			_, err = client.CreateErrorPage(errorPage, domainID)
+		}
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error saving error page %d", code),
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		current[strconv.Itoa(code)] = hash
+	}
+
+	for code := range current {
+		errorCode, _ := strconv.Atoi(code)
+		if _, ok := pages[errorCode]; ok {
+			continue
+		}
+
+		_, err := client.DeleteErrorPage(&myrasec.ErrorPage{
+			ErrorCode:     errorCode,
+			SubDomainName: subDomainName,
+		}, domainID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error deleting error page %d", errorCode),
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		delete(current, code)
+	}
+
+	d.Set("template_hash", createContentHash(tmpl))
+
+	return diags
+}
+
+// listErrorPageSetPages returns the error pages of the subdomain by their error code
+func listErrorPageSetPages(meta any, domainID int, subDomainName string) (map[int]*myrasec.ErrorPage, diag.Diagnostics) {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+	result := map[int]*myrasec.ErrorPage{}
+
+	page := 1
+	pageSize := 250
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+		"page":     strconv.Itoa(page),
+		"search":   myrasec.RemoveTrailingDot(subDomainName),
+	}
+	for {
+		params["page"] = strconv.Itoa(page)
+		pages, err := client.ListErrorPages(domainID, params)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error loading error pages",
+				Detail:   formatError(err),
+			})
+			return nil, diags
+		}
+
+		for i := range pages {
+			if myrasec.EnsureTrailingDot(pages[i].SubDomainName) == myrasec.EnsureTrailingDot(subDomainName) {
+				result[pages[i].ErrorCode] = &pages[i]
+			}
+		}
+
+		if len(pages) < pageSize {
+			break
+		}
+		page++
+	}
+
+	return result, diags
+}
+
+// renderErrorPageSet renders the template for every error code
+func renderErrorPageSet(tmpl string, engine string, variables map[string]any, errorCodes *schema.Set, codeVariables []any) (map[int]string, error) {
+	perCode := map[int]map[string]any{}
+	for _, cv := range codeVariables {
+		m := cv.(map[string]any)
+		code := m["error_code"].(int)
+		if !errorCodes.Contains(code) {
+			return nil, fmt.Errorf("code_variables contains the error code [%d] that is not part of error_codes", code)
+		}
+		vars, _ := m["variables"].(map[string]any)
+		perCode[code] = vars
+	}
+
+	var execute func(buf *bytes.Buffer, data errorPageTemplateData) error
+	switch engine {
+	case "text":
+		t, err := texttemplate.New("error_page").Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		execute = func(buf *bytes.Buffer, data errorPageTemplateData) error {
+			return t.Execute(buf, data)
+		}
+	default:
+		t, err := htmltemplate.New("error_page").Option("missingkey=error").Parse(tmpl)
+		if err != nil {
+			return nil, err
+		}
+		execute = func(buf *bytes.Buffer, data errorPageTemplateData) error {
+			return t.Execute(buf, data)
+		}
+	}
+
+	pages := map[int]string{}
+	for _, c := range errorCodes.List() {
+		code := c.(int)
+
+		data := errorPageTemplateData{
+			ErrorCode: code,
+			Vars:      map[string]string{},
+		}
+		for k, v := range variables {
+			data.Vars[k] = v.(string)
+		}
+		for k, v := range perCode[code] {
+			data.Vars[k] = v.(string)
+		}
+
+		var buf bytes.Buffer
+		if err := execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("unable to render the error page for [%d]: %s", code, err)
+		}
+		pages[code] = buf.String()
+	}
+
+	return pages, nil
+}
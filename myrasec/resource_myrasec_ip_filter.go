@@ -75,9 +75,24 @@ func resourceMyrasecIPFilter() *schema.Resource {
 				Description: "The IP you want to whitelist or blacklist. By using CIDR notation on IPv4 IPs, you are able to define whole subnets.",
 			},
 			"expire_date": {
-				Type:        schema.TypeString,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateExpireDate,
+				DiffSuppressFunc: suppressEquivalentDates,
+				Description:      "Expire date schedules the deaktivation of the filter. If none is set, the filter will be active until manual deactivation.",
+			},
+			"expiry_warning_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Emit a warning when the IP filter expires within the given number of days.",
+			},
+			"remove_on_expiry": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Expire date schedules the deaktivation of the filter. If none is set, the filter will be active until manual deactivation.",
+				Default:     false,
+				Description: "Treat the IP filter as removed once the expire date has passed.",
 			},
 			"enabled": {
 				Type:        schema.TypeBool,
@@ -101,6 +116,9 @@ func resourceMyrasecIPFilter() *schema.Resource {
 			Create: schema.DefaultTimeout(30 * time.Second),
 			Update: schema.DefaultTimeout(30 * time.Second),
 		},
+		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i any) error {
+			return validateRemoveOnExpiry(rd, "IP filter")
+		},
 	}
 }
 
@@ -170,8 +188,16 @@ func resourceMyrasecIPFilterRead(ctx context.Context, d *schema.ResourceData, me
 		return nil
 	}
 
+	if d.Get("remove_on_expiry").(bool) && isExpired(filter.ExpireDate) {
+		log.Printf("[INFO] IP filter [%d] has expired and is removed from the state", filterID)
+		d.SetId("")
+		return diags
+	}
+
 	setIPFilterData(d, filter, domainID)
 
+	diags = append(diags, checkExpireDate(fmt.Sprintf("IP filter [%s]", filter.Value), filter.ExpireDate, d.Get("expiry_warning_days").(int), "Remove it from the configuration or update the expire_date.")...)
+
 	return diags
 }
 
@@ -0,0 +1,516 @@
+package myrasec
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceMyrasecIPFilterList ...
+func resourceMyrasecIPFilterList() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecIPFilterListCreate,
+		ReadContext:   resourceMyrasecIPFilterListRead,
+		UpdateContext: resourceMyrasecIPFilterListUpdate,
+		DeleteContext: resourceMyrasecIPFilterListDelete,
+		Schema: map[string]*schema.Schema{
+			"subdomain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					name := i.(string)
+					if myrasec.IsGeneralDomainName(name) {
+						return name
+					}
+					return strings.ToLower(name)
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return myrasec.RemoveTrailingDot(old) == myrasec.RemoveTrailingDot(new)
+				},
+				Description: "The Subdomain for the IP filters.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					return strings.ToUpper(i.(string))
+				},
+				ValidateFunc: validation.StringInSlice([]string{"BLACKLIST", "WHITELIST", "WHITELIST_REQUEST_LIMITER"}, false),
+				Description:  "Type of the IP filters.",
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "A comment to describe the IP filters.",
+			},
+			"cidrs": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateIPFilterCIDR,
+				},
+				AtLeastOneOf: []string{"cidrs", "cidrs_content"},
+				Description:  "IPs and subnets in CIDR notation.",
+			},
+			"cidrs_content": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"cidrs", "cidrs_content"},
+				Description:  "Newline-separated IPs and subnets in CIDR notation, e.g. the content of a threat feed.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Adopt existing IP filters of the subdomain with the same type and value. Otherwise these IP filters are reported as conflict.",
+			},
+			"values": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The aggregated IPs and subnets of the IP filters.",
+			},
+			"filter_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "IDs of the IP filters by value.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Stores domain Id for subdomain.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		CustomizeDiff: resourceCustomizeDiffIPFilterList,
+	}
+}
+
+// resourceCustomizeDiffIPFilterList parses and aggregates the CIDRs
+func resourceCustomizeDiffIPFilterList(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if !d.NewValueKnown("cidrs") || !d.NewValueKnown("cidrs_content") {
+		if err := d.SetNewComputed("values"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("filter_ids")
+	}
+
+	values, err := buildIPFilterListValues(d.Get("cidrs").(*schema.Set).List(), d.Get("cidrs_content").(string))
+	if err != nil {
+		return err
+	}
+
+	current := map[string]bool{}
+	for _, v := range d.Get("values").(*schema.Set).List() {
+		current[v.(string)] = true
+	}
+	changed := d.Id() == "" || len(current) != len(values)
+	for _, v := range values {
+		if !current[v] {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := d.SetNew("values", values); err != nil {
+		return err
+	}
+	return d.SetNewComputed("filter_ids")
+}
+
+// resourceMyrasecIPFilterListCreate ...
+func resourceMyrasecIPFilterListCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	subDomainName := myrasec.RemoveTrailingDot(d.Get("subdomain_name").(string))
+	d.SetId(fmt.Sprintf("%s:%d", subDomainName, time.Now().Unix()))
+
+	diags := syncIPFilterList(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecIPFilterListRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecIPFilterListRead ...
+func resourceMyrasecIPFilterListRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listIPFilterListFilters(meta, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	// IP filters that were deleted outside of Terraform are removed from the state
+	comment := d.Get("comment").(string)
+	values := []any{}
+	ids := map[string]any{}
+	for _, id := range d.Get("filter_ids").(map[string]any) {
+		f, ok := remote[id.(int)]
+		if !ok || !strings.EqualFold(f.Type, d.Get("type").(string)) {
+			continue
+		}
+
+		value := canonicalIPFilterValue(f.Value)
+		values = append(values, value)
+		ids[value] = f.ID
+		if f.Comment != comment {
+			comment = f.Comment
+		}
+	}
+
+	d.Set("values", values)
+	d.Set("filter_ids", ids)
+	d.Set("comment", comment)
+	d.Set("domain_id", domainID)
+
+	return diags
+}
+
+// resourceMyrasecIPFilterListUpdate ...
+func resourceMyrasecIPFilterListUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[INFO] Updating IP filter list: %v", d.Id())
+
+	diags := syncIPFilterList(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecIPFilterListRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecIPFilterListDelete ...
+func resourceMyrasecIPFilterListDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	log.Printf("[INFO] Deleting IP filter list: %v", d.Id())
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listIPFilterListFilters(meta, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, id := range d.Get("filter_ids").(map[string]any) {
+		f, ok := remote[id.(int)]
+		if !ok {
+			continue
+		}
+
+		_, err := client.DeleteIPFilter(f, domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting IP filter",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+	client.PruneCache()
+
+	return diags
+}
+
+// syncIPFilterList creates, updates and deletes the IP filters to match the planned values
+func syncIPFilterList(d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+	// the reads during the sync may still hit the cache, prune it so the Read that follows sees the changes
+	defer client.PruneCache()
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listIPFilterListFilters(meta, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	filterType := strings.ToUpper(d.Get("type").(string))
+	comment := d.Get("comment").(string)
+
+	desired := map[string]bool{}
+	for _, v := range d.Get("values").(*schema.Set).List() {
+		desired[v.(string)] = true
+	}
+
+	o, _ := d.GetChange("filter_ids")
+	current := map[string]*myrasec.IPFilter{}
+	managed := map[int]bool{}
+	for _, id := range o.(map[string]any) {
+		managed[id.(int)] = true
+		if f, ok := remote[id.(int)]; ok {
+			current[canonicalIPFilterValue(f.Value)] = f
+		}
+	}
+
+	// IP filters of the subdomain with the same type and value are only adopted if adopt_existing is set,
+	// they may be managed by a myrasec_ip_filter resource or in the Myra UI
+	unmanaged := map[string]*myrasec.IPFilter{}
+	for _, f := range remote {
+		if !managed[f.ID] && strings.EqualFold(f.Type, filterType) {
+			unmanaged[canonicalIPFilterValue(f.Value)] = f
+		}
+	}
+
+	values := make([]string, 0, len(desired))
+	for value := range desired {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	if !d.Get("adopt_existing").(bool) {
+		conflicts := []string{}
+		for _, value := range values {
+			if f, ok := unmanaged[value]; ok {
+				if _, ok := current[value]; !ok {
+					conflicts = append(conflicts, fmt.Sprintf("%s (ID = %d)", value, f.ID))
+				}
+			}
+		}
+		if len(conflicts) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "IP filters already exist",
+				Detail:   formatError(fmt.Errorf("the %s IP filters [%s] of [%s] already exist and are not managed by this IP filter list. Remove them or set adopt_existing = true to manage them with this IP filter list", filterType, strings.Join(conflicts, ", "), subDomainName)),
+			})
+			return diags
+		}
+	}
+
+	// keep track of the managed IP filters, also if an error occurs
+	defer func() {
+		ids := map[string]any{}
+		for value, f := range current {
+			ids[value] = f.ID
+		}
+		d.Set("filter_ids", ids)
+		d.Set("domain_id", domainID)
+	}()
+
+	for value, f := range current {
+		if desired[value] {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting IP filter: %v", f.ID)
+		_, err := client.DeleteIPFilter(f, domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting IP filter",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		delete(current, value)
+	}
+
+	for _, value := range values {
+		existing, ok := current[value]
+		if !ok {
+			existing, ok = unmanaged[value]
+		}
+
+		if !ok {
+			resp, err := client.CreateIPFilter(&myrasec.IPFilter{
+				Value:         value,
+				Type:          filterType,
+				Comment:       comment,
+				Enabled:       true,
+				SubDomainName: subDomainName,
+			}, domainID, subDomainName)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error creating IP filter",
+					Detail:   formatError(fmt.Errorf("[%s]: %s", value, err.Error())),
+				})
+				return diags
+			}
+			current[value] = resp
+			continue
+		}
+
+		current[value] = existing
+		if existing.Comment == comment && existing.Enabled {
+			continue
+		}
+
+		filter := *existing
+		filter.Comment = comment
+		filter.Enabled = true
+		resp, err := client.UpdateIPFilter(&filter, domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error updating IP filter",
+				Detail:   formatError(fmt.Errorf("[%s]: %s", value, err.Error())),
+			})
+			return diags
+		}
+		current[value] = resp
+	}
+
+	return diags
+}
+
+// listIPFilterListFilters returns all IP filters of the subdomain by ID
+func listIPFilterListFilters(meta any, subDomainName string) (map[int]*myrasec.IPFilter, diag.Diagnostics) {
+	filters, diags := listIPFilters(meta, subDomainName, map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := map[int]*myrasec.IPFilter{}
+	for i := range filters {
+		result[filters[i].ID] = &filters[i]
+	}
+	return result, diags
+}
+
+// validateIPFilterCIDR checks that the value is an IPv4 or IPv6 address or subnet in CIDR notation
+func validateIPFilterCIDR(i any, k string) (warnings []string, errors []error) {
+	if _, err := parseIPFilterPrefix(i.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err.Error()))
+	}
+	return warnings, errors
+}
+
+// buildIPFilterListValues parses the CIDRs of the set and the content and returns the aggregated subnets
+func buildIPFilterListValues(cidrs []any, content string) ([]string, error) {
+	var prefixes []netip.Prefix
+
+	for _, c := range cidrs {
+		prefix, err := parseIPFilterPrefix(c.(string))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		// threat feeds use # or ; for comments
+		line, _, _ = strings.Cut(line, "#")
+		line, _, _ = strings.Cut(line, ";")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		prefix, err := parseIPFilterPrefix(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d of cidrs_content: %s", i+1, err.Error())
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	var values []string
+	for _, prefix := range aggregateIPPrefixes(prefixes) {
+		values = append(values, formatIPFilterPrefix(prefix))
+	}
+	return values, nil
+}
+
+// parseIPFilterPrefix parses an IPv4 or IPv6 address or subnet in CIDR notation
+func parseIPFilterPrefix(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+
+	if !strings.Contains(value, "/") {
+		addr, err := netip.ParseAddr(value)
+		if err != nil || addr.Zone() != "" {
+			return netip.Prefix{}, fmt.Errorf("[%s] is not a valid IP address", value)
+		}
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("[%s] is not a valid CIDR notation", value)
+	}
+	return prefix.Masked(), nil
+}
+
+// formatIPFilterPrefix returns single addresses without prefix length and subnets in CIDR notation
+func formatIPFilterPrefix(prefix netip.Prefix) string {
+	if prefix.IsSingleIP() {
+		return prefix.Addr().String()
+	}
+	return prefix.String()
+}
+
+// canonicalIPFilterValue returns the value of an IP filter in the notation of formatIPFilterPrefix
+func canonicalIPFilterValue(value string) string {
+	prefix, err := parseIPFilterPrefix(value)
+	if err != nil {
+		return strings.ToLower(value)
+	}
+	return formatIPFilterPrefix(prefix)
+}
+
+// aggregateIPPrefixes removes the subnets that are contained in other subnets and merges adjacent subnets
+func aggregateIPPrefixes(prefixes []netip.Prefix) []netip.Prefix {
+	sorted := make([]netip.Prefix, len(prefixes))
+	copy(sorted, prefixes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := sorted[i].Addr().Compare(sorted[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return sorted[i].Bits() < sorted[j].Bits()
+	})
+
+	var result []netip.Prefix
+	for _, prefix := range sorted {
+		if len(result) > 0 && result[len(result)-1].Overlaps(prefix) {
+			// the previous subnet starts before or at the same address and is not smaller, so it contains this subnet
+			continue
+		}
+		result = append(result, prefix)
+
+		// merge the last two subnets as long as they are the two halves of a larger subnet
+		for len(result) > 1 {
+			a, b := result[len(result)-2], result[len(result)-1]
+			if a.Bits() != b.Bits() || a.Bits() == 0 || a.Addr().Is4() != b.Addr().Is4() {
+				break
+			}
+			parent := netip.PrefixFrom(a.Addr(), a.Bits()-1).Masked()
+			if parent.Addr() != a.Addr() || !parent.Contains(b.Addr()) {
+				break
+			}
+			result = append(result[:len(result)-2], parent)
+		}
+	}
+
+	return result
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var maintenanceVariableRegexp = regexp.MustCompile(`\{\{\s*(start|end)\s*\}\}`)
+
 // resourceMyrasecMaintenance ...
 func resourceMyrasecMaintenance() *schema.Resource {
 	return &schema.Resource{
@@ -95,15 +98,31 @@ func resourceMyrasecMaintenance() *schema.Resource {
 				},
 			},
 			"content": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "HTML content of the maintenance.",
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: suppressRenderedContentDiff,
+				ExactlyOneOf:     []string{"content", "template_id", "template_name"},
+			},
+			"content_source_dir": htmlContentSourceDirSchema(),
+			"template_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "ID of the maintenance template that is used as content of the maintenance.",
+				ExactlyOneOf: []string{"content", "template_id", "template_name"},
+			},
+			"template_name": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "HTML content of the maintenance.",
-				ValidateFunc: validation.NoZeroValues,
-				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-					oldHash := d.Get("content_hash")
-					newHash := createContentHash(newValue)
-					return oldHash == newHash
-				},
+				Optional:     true,
+				Description:  "Name of the maintenance template that is used as content of the maintenance.",
+				ExactlyOneOf: []string{"content", "template_id", "template_name"},
+			},
+			"template_variables": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Replaces {{start}} and {{end}} in the content of the maintenance template with the start and end of the maintenance.",
 			},
 			"content_hash": {
 				Type:     schema.TypeString,
@@ -139,11 +158,48 @@ func resourceMyrasecMaintenance() *schema.Resource {
 				return fmt.Errorf("end date should not be before start date")
 			}
 
-			return nil
+			if err := resourceCustomizeDiffHTMLContent(ctx, rd, i); err != nil {
+				return err
+			}
+
+			return resourceCustomizeDiffMaintenanceTemplate(rd, i)
 		},
 	}
 }
 
+// resourceCustomizeDiffMaintenanceTemplate tracks changes of the maintenance template as a change of the content hash
+func resourceCustomizeDiffMaintenanceTemplate(d *schema.ResourceDiff, meta any) error {
+	for _, k := range []string{"subdomain_name", "template_id", "template_name", "template_variables", "start", "end"} {
+		if !d.NewValueKnown(k) {
+			return d.SetNewComputed("content_hash")
+		}
+	}
+
+	templateID := d.Get("template_id").(int)
+	templateName := d.Get("template_name").(string)
+	if templateID == 0 && templateName == "" {
+		return nil
+	}
+
+	domainID, err := findDomainIDBySubdomainName(meta, d.Get("subdomain_name").(string), d.Get("domain_id").(int))
+	if err != nil {
+		return err
+	}
+
+	start, _ := types.ParseDate(d.Get("start").(string))
+	end, _ := types.ParseDate(d.Get("end").(string))
+
+	content, err := buildMaintenanceTemplateContent(meta, domainID, templateID, templateName, d.Get("template_variables").(bool), start, end)
+	if err != nil {
+		return err
+	}
+
+	if hash := createContentHash(content); hash != d.Get("content_hash").(string) {
+		return d.SetNew("content_hash", hash)
+	}
+	return nil
+}
+
 // resourceMyrasecMaintenanceCreate
 func resourceMyrasecMaintenanceCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*myrasec.API)
@@ -165,6 +221,19 @@ func resourceMyrasecMaintenanceCreate(ctx context.Context, d *schema.ResourceDat
 		return diags
 	}
 
+	if d.Get("template_id").(int) > 0 || d.Get("template_name").(string) != "" {
+		content, err := buildMaintenanceTemplateContent(meta, domainID, d.Get("template_id").(int), d.Get("template_name").(string), d.Get("template_variables").(bool), maintenance.Start, maintenance.End)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error loading maintenance template",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		maintenance.Content = content
+	}
+
 	resp, err := client.CreateMaintenance(maintenance, domainID, subDomainName)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -246,6 +315,19 @@ func resourceMyrasecMaintenanceUpdate(ctx context.Context, d *schema.ResourceDat
 		return diags
 	}
 
+	if d.Get("template_id").(int) > 0 || d.Get("template_name").(string) != "" {
+		content, err := buildMaintenanceTemplateContent(meta, domainID, d.Get("template_id").(int), d.Get("template_name").(string), d.Get("template_variables").(bool), maintenance.Start, maintenance.End)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error loading maintenance template",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		maintenance.Content = content
+	}
+
 	maintenance, err = client.UpdateMaintenance(maintenance, domainID, subDomainName)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -338,8 +420,13 @@ func resourceMyrasecMaintenanceImport(ctx context.Context, d *schema.ResourceDat
 
 // buildMaintenance
 func buildMaintenance(d *schema.ResourceData) (*myrasec.Maintenance, error) {
+	content, err := renderHTMLContent(d.Get("content").(string), d.Get("content_source_dir").(string))
+	if err != nil {
+		return nil, err
+	}
+
 	maintenance := &myrasec.Maintenance{
-		Content: d.Get("content").(string),
+		Content: content,
 		FQDN:    d.Get("subdomain_name").(string),
 	}
 
@@ -379,6 +466,59 @@ func buildMaintenance(d *schema.ResourceData) (*myrasec.Maintenance, error) {
 	return maintenance, nil
 }
 
+// buildMaintenanceTemplateContent returns the content of the maintenance template with the passed ID or name
+func buildMaintenanceTemplateContent(meta any, domainID int, templateID int, templateName string, variables bool, start *types.DateTime, end *types.DateTime) (string, error) {
+	var template *myrasec.MaintenanceTemplate
+	var diags diag.Diagnostics
+
+	if templateID > 0 {
+		template, diags = findMaintenanceTemplate(templateID, meta, domainID)
+	} else {
+		template, diags = findMaintenanceTemplateByName(templateName, meta, domainID)
+	}
+	if diags.HasError() {
+		return "", fmt.Errorf("unable to load maintenance templates for domain [%d]", domainID)
+	}
+	if template == nil {
+		if templateID > 0 {
+			return "", fmt.Errorf("unable to find maintenance template with ID = [%d]", templateID)
+		}
+		return "", fmt.Errorf("unable to find maintenance template with name [%s]", templateName)
+	}
+
+	if !variables {
+		return template.Content, nil
+	}
+	return substituteMaintenanceVariables(template.Content, start, end), nil
+}
+
+// substituteMaintenanceVariables replaces {{start}} and {{end}} with time elements. A script shows the times in the locale of the visitor
+func substituteMaintenanceVariables(content string, start *types.DateTime, end *types.DateTime) string {
+	replaced := false
+	content = maintenanceVariableRegexp.ReplaceAllStringFunc(content, func(match string) string {
+		date := start
+		if maintenanceVariableRegexp.FindStringSubmatch(match)[1] == "end" {
+			date = end
+		}
+		if date == nil {
+			return match
+		}
+
+		replaced = true
+		return fmt.Sprintf(`<time datetime="%s" data-maintenance-time>%s</time>`, date.Format(time.RFC3339), date.Format("02 Jan 2006 15:04 MST"))
+	})
+
+	if !replaced {
+		return content
+	}
+
+	script := `<script>document.querySelectorAll("time[data-maintenance-time]").forEach(function(t){t.textContent=new Date(t.dateTime).toLocaleString()})</script>`
+	if i := strings.LastIndex(strings.ToLower(content), "</body>"); i >= 0 {
+		return content[:i] + script + content[i:]
+	}
+	return content + script
+}
+
 // findMaintenance
 func findMaintenance(maintenanceID int, meta any, subDomainName string, domainID int) (*myrasec.Maintenance, diag.Diagnostics) {
 	var diags diag.Diagnostics
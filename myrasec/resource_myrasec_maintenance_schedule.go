@@ -0,0 +1,573 @@
+package myrasec
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/Myra-Security-GmbH/myrasec-go/v2/pkg/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceMyrasecMaintenanceSchedule ...
+func resourceMyrasecMaintenanceSchedule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecMaintenanceScheduleCreate,
+		ReadContext:   resourceMyrasecMaintenanceScheduleRead,
+		UpdateContext: resourceMyrasecMaintenanceScheduleUpdate,
+		DeleteContext: resourceMyrasecMaintenanceScheduleDelete,
+		Schema: map[string]*schema.Schema{
+			"subdomain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					name := i.(string)
+					if myrasec.IsGeneralDomainName(name) {
+						return name
+					}
+					return strings.ToLower(name)
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return myrasec.RemoveTrailingDot(old) == myrasec.RemoveTrailingDot(new)
+				},
+				Description: "The subdomain name for the maintenances.",
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Stores domain ID of the subdomain.",
+			},
+			"recurrence": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cron expression (minute hour day-of-month month day-of-week) or RRULE (FREQ=WEEKLY;BYDAY=SU;BYHOUR=2) for the start of the maintenances.",
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "UTC",
+				Description: "Timezone of the recurrence.",
+				ValidateFunc: func(i any, k string) (warnings []string, errors []error) {
+					if _, err := time.LoadLocation(i.(string)); err != nil {
+						errors = append(errors, fmt.Errorf("%q is not a valid timezone: %s", k, err.Error()))
+					}
+					return warnings, errors
+				},
+			},
+			"duration": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Duration of a single maintenance, e.g. 2h or 90m.",
+				ValidateFunc: func(i any, k string) (warnings []string, errors []error) {
+					duration, err := time.ParseDuration(i.(string))
+					if err != nil || duration < time.Minute {
+						errors = append(errors, fmt.Errorf("%q must be a duration of at least 1m", k))
+					}
+					return warnings, errors
+				},
+			},
+			"starts_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "No maintenances are created before this date. It is also the start date of a RRULE with INTERVAL.",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"occurrences": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				Description:  "Number of upcoming maintenances that are created.",
+				ValidateFunc: validation.IntBetween(1, 52),
+			},
+			"content": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "HTML content of the maintenances.",
+				DiffSuppressFunc: suppressRenderedContentDiff,
+				ExactlyOneOf:     []string{"content", "maintenance_template_id"},
+			},
+			"content_source_dir": htmlContentSourceDirSchema(),
+			"maintenance_template_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "ID of the maintenance template that is used as content of the maintenances.",
+				ExactlyOneOf: []string{"content", "maintenance_template_id"},
+			},
+			"content_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"windows": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Start and end of the upcoming maintenances.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"end": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"maintenance_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the upcoming maintenances.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+		CustomizeDiff: resourceCustomizeDiffMaintenanceSchedule,
+	}
+}
+
+// resourceCustomizeDiffMaintenanceSchedule calculates the upcoming maintenance windows and the hash of the content
+func resourceCustomizeDiffMaintenanceSchedule(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if err := resourceCustomizeDiffHTMLContent(ctx, d, meta); err != nil {
+		return err
+	}
+
+	for _, k := range []string{"recurrence", "timezone", "duration", "starts_at", "occurrences"} {
+		if !d.NewValueKnown(k) {
+			if err := d.SetNewComputed("windows"); err != nil {
+				return err
+			}
+			return d.SetNewComputed("maintenance_ids")
+		}
+	}
+
+	windows, err := buildMaintenanceScheduleWindows(d.Get("recurrence").(string), d.Get("timezone").(string), d.Get("duration").(string), d.Get("starts_at").(string), d.Get("occurrences").(int))
+	if err != nil {
+		return err
+	}
+
+	// windows that have already started are only kept if the maintenance exists, the skipped windows are replaced by the following ones
+	current := d.Get("windows").([]any)
+	if skipped := len(windows) - len(skipStartedMaintenanceScheduleWindows(windows, current, time.Now())); skipped > 0 {
+		windows, err = buildMaintenanceScheduleWindows(d.Get("recurrence").(string), d.Get("timezone").(string), d.Get("duration").(string), d.Get("starts_at").(string), d.Get("occurrences").(int)+skipped)
+		if err != nil {
+			return err
+		}
+	}
+	windows = skipStartedMaintenanceScheduleWindows(windows, current, time.Now())
+	if len(windows) == 0 {
+		return fmt.Errorf("the recurrence has no occurrences within the next %d days", maxRecurrenceDays)
+	}
+
+	flattened := flattenMaintenanceScheduleWindows(windows)
+	if !equalMaintenanceScheduleWindows(current, flattened) {
+		if err := d.SetNew("windows", flattened); err != nil {
+			return err
+		}
+		if err := d.SetNewComputed("maintenance_ids"); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("content") || !d.NewValueKnown("content_source_dir") || !d.NewValueKnown("maintenance_template_id") {
+		return d.SetNewComputed("content_hash")
+	}
+
+	domainID, err := findDomainIDBySubdomainName(meta, d.Get("subdomain_name").(string), d.Get("domain_id").(int))
+	if err != nil {
+		return err
+	}
+
+	content, _ := rawConfigString(d.GetRawConfig(), "content")
+	content, err = buildMaintenanceScheduleContent(content, d.Get("content_source_dir").(string), d.Get("maintenance_template_id").(int), domainID, meta)
+	if err != nil {
+		return err
+	}
+
+	if hash := createContentHash(content); hash != d.Get("content_hash").(string) {
+		return d.SetNew("content_hash", hash)
+	}
+	return nil
+}
+
+// resourceMyrasecMaintenanceScheduleCreate ...
+func resourceMyrasecMaintenanceScheduleCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	subDomainName := myrasec.RemoveTrailingDot(d.Get("subdomain_name").(string))
+	d.SetId(fmt.Sprintf("%s:%d", subDomainName, time.Now().Unix()))
+
+	diags := syncMaintenanceSchedule(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecMaintenanceScheduleRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecMaintenanceScheduleRead ...
+func resourceMyrasecMaintenanceScheduleRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listSubdomainMaintenances(meta, domainID, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	loc, err := time.LoadLocation(d.Get("timezone").(string))
+	if err != nil {
+		loc = time.UTC
+	}
+
+	// elapsed and deleted maintenances are removed from the state
+	ids := []int{}
+	windows := []recurrenceWindow{}
+	contentHash := d.Get("content_hash").(string)
+	for _, id := range d.Get("maintenance_ids").([]any) {
+		m, ok := remote[id.(int)]
+		if !ok || m.End == nil || !m.End.After(time.Now()) {
+			continue
+		}
+		ids = append(ids, m.ID)
+		windows = append(windows, recurrenceWindow{Start: m.Start.In(loc), End: m.End.In(loc)})
+		if len(ids) == 1 {
+			contentHash = createContentHash(m.Content)
+		}
+	}
+
+	d.Set("maintenance_ids", ids)
+	d.Set("windows", flattenMaintenanceScheduleWindows(windows))
+	d.Set("content", "")
+	d.Set("content_hash", contentHash)
+	d.Set("domain_id", domainID)
+
+	return diags
+}
+
+// resourceMyrasecMaintenanceScheduleUpdate ...
+func resourceMyrasecMaintenanceScheduleUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[INFO] Updating maintenance schedule: %v", d.Id())
+
+	diags := syncMaintenanceSchedule(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecMaintenanceScheduleRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecMaintenanceScheduleDelete ...
+func resourceMyrasecMaintenanceScheduleDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	log.Printf("[INFO] Deleting maintenance schedule: %v", d.Id())
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listSubdomainMaintenances(meta, domainID, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, id := range d.Get("maintenance_ids").([]any) {
+		m, ok := remote[id.(int)]
+		if !ok {
+			continue
+		}
+
+		_, err := client.DeleteMaintenance(m, domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting maintenance",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// syncMaintenanceSchedule creates, updates and deletes the maintenances to match the planned windows
+func syncMaintenanceSchedule(d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+	// the reads during the sync may still hit the cache, prune it so the Read that follows sees the changes
+	defer client.PruneCache()
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	content, _ := rawConfigString(d.GetRawConfig(), "content")
+	content, err := buildMaintenanceScheduleContent(content, d.Get("content_source_dir").(string), d.Get("maintenance_template_id").(int), domainID, meta)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error building maintenance content",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	remote, diags := listSubdomainMaintenances(meta, domainID, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	oldIDs, _ := d.GetChange("maintenance_ids")
+	contentChanged := d.HasChange("content_hash")
+
+	used := map[int]bool{}
+	deleted := map[int]bool{}
+	ids := []int{}
+
+	// keep track of the created maintenances and the maintenances that are not deleted yet, also if an error occurs
+	defer func() {
+		current := append([]int{}, ids...)
+		for _, id := range oldIDs.([]any) {
+			if _, ok := remote[id.(int)]; ok && !used[id.(int)] && !deleted[id.(int)] {
+				current = append(current, id.(int))
+			}
+		}
+		d.Set("maintenance_ids", current)
+		d.Set("domain_id", domainID)
+	}()
+	for _, w := range d.Get("windows").([]any) {
+		window := w.(map[string]any)
+		start, err := types.ParseDate(window["start"].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		end, err := types.ParseDate(window["end"].(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		var existing *myrasec.Maintenance
+		for _, id := range oldIDs.([]any) {
+			m, ok := remote[id.(int)]
+			if ok && !used[m.ID] && m.Start != nil && m.End != nil && m.Start.Equal(start.Time) && m.End.Equal(end.Time) {
+				existing = m
+				break
+			}
+		}
+
+		if existing == nil && !start.After(time.Now()) {
+			// the window started between the plan and the apply
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Skipped maintenance window",
+				Detail:   fmt.Sprintf("The maintenance window from [%s] to [%s] has already started, no maintenance was created for it.", window["start"].(string), window["end"].(string)),
+			})
+			continue
+		}
+
+		if existing == nil {
+			created, err := client.CreateMaintenance(&myrasec.Maintenance{
+				Start:   start,
+				End:     end,
+				Content: content,
+				FQDN:    subDomainName,
+			}, domainID, subDomainName)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error creating maintenance",
+					Detail:   formatError(err),
+				})
+				return diags
+			}
+			ids = append(ids, created.ID)
+			used[created.ID] = true
+			continue
+		}
+
+		used[existing.ID] = true
+		ids = append(ids, existing.ID)
+
+		if contentChanged {
+			existing.Content = content
+			_, err := client.UpdateMaintenance(existing, domainID, subDomainName)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error updating maintenance",
+					Detail:   formatError(err),
+				})
+				return diags
+			}
+		}
+	}
+
+	for _, id := range oldIDs.([]any) {
+		m, ok := remote[id.(int)]
+		if !ok || used[m.ID] {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting maintenance: %v", m.ID)
+		_, err := client.DeleteMaintenance(m, domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting maintenance",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		deleted[m.ID] = true
+	}
+
+	return diags
+}
+
+// buildMaintenanceScheduleWindows returns the next windows of the recurrence
+func buildMaintenanceScheduleWindows(value string, timezone string, duration string, startsAt string, count int) ([]recurrenceWindow, error) {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return nil, err
+	}
+
+	var anchor time.Time
+	if startsAt != "" {
+		anchor, err = time.Parse(time.RFC3339, startsAt)
+		if err != nil {
+			return nil, err
+		}
+		anchor = anchor.In(loc)
+	}
+
+	r, err := parseRecurrence(value, anchor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence: %s", err.Error())
+	}
+
+	return r.nextOccurrences(time.Now(), d, count, loc), nil
+}
+
+// skipStartedMaintenanceScheduleWindows removes the windows that have already started, unless they are part of the current windows
+func skipStartedMaintenanceScheduleWindows(windows []recurrenceWindow, current []any, now time.Time) []recurrenceWindow {
+	result := []recurrenceWindow{}
+	for _, w := range windows {
+		if w.Start.After(now) {
+			result = append(result, w)
+			continue
+		}
+
+		window := flattenMaintenanceScheduleWindows([]recurrenceWindow{w})[0].(map[string]any)
+		for _, c := range current {
+			if c.(map[string]any)["start"] == window["start"] && c.(map[string]any)["end"] == window["end"] {
+				result = append(result, w)
+				break
+			}
+		}
+	}
+	return result
+}
+
+// buildMaintenanceScheduleContent returns the rendered content or the content of the maintenance template
+func buildMaintenanceScheduleContent(content string, sourceDir string, templateID int, domainID int, meta any) (string, error) {
+	if templateID > 0 {
+		template, diags := findMaintenanceTemplate(templateID, meta, domainID)
+		if diags.HasError() {
+			return "", fmt.Errorf("unable to load maintenance template [%d]", templateID)
+		}
+		if template == nil {
+			return "", fmt.Errorf("unable to find maintenance template [%d]", templateID)
+		}
+		return template.Content, nil
+	}
+
+	return renderHTMLContent(content, sourceDir)
+}
+
+// listSubdomainMaintenances returns all maintenances of the passed subdomain by ID
+func listSubdomainMaintenances(meta any, domainID int, subDomainName string) (map[int]*myrasec.Maintenance, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	client := meta.(*myrasec.API)
+	maintenances := map[int]*myrasec.Maintenance{}
+
+	page := 1
+	pageSize := 100
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+	}
+
+	for {
+		params["page"] = strconv.Itoa(page)
+		res, err := client.ListMaintenances(domainID, subDomainName, params)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error loading maintenances",
+				Detail:   formatError(err),
+			})
+			return nil, diags
+		}
+
+		for i := range res {
+			maintenances[res[i].ID] = &res[i]
+		}
+
+		if len(res) < pageSize {
+			break
+		}
+		page++
+	}
+
+	return maintenances, diags
+}
+
+// flattenMaintenanceScheduleWindows ...
+func flattenMaintenanceScheduleWindows(windows []recurrenceWindow) []any {
+	result := []any{}
+	for _, w := range windows {
+		result = append(result, map[string]any{
+			"start": w.Start.Format(time.RFC3339),
+			"end":   w.End.Format(time.RFC3339),
+		})
+	}
+	return result
+}
+
+// equalMaintenanceScheduleWindows compares the windows from the state with the calculated windows
+func equalMaintenanceScheduleWindows(current []any, windows []any) bool {
+	if len(current) != len(windows) {
+		return false
+	}
+
+	for i := range current {
+		c := current[i].(map[string]any)
+		w := windows[i].(map[string]any)
+		if c["start"] != w["start"] || c["end"] != w["end"] {
+			return false
+		}
+	}
+	return true
+}
@@ -60,15 +60,12 @@ func resourceMyrasecMaintenanceTemplate() *schema.Resource {
 				Description: "Name of the maintenance template.",
 			},
 			"content": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "HTML content of the maintenance template.",
-				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-					oldHash := d.Get("content_hash")
-					newHash := createContentHash(newValue)
-					return oldHash == newHash
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "HTML content of the maintenance template.",
+				DiffSuppressFunc: suppressRenderedContentDiff,
 			},
+			"content_source_dir": htmlContentSourceDirSchema(),
 			"content_hash": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -78,6 +75,7 @@ func resourceMyrasecMaintenanceTemplate() *schema.Resource {
 			Create: schema.DefaultTimeout(30 * time.Second),
 			Update: schema.DefaultTimeout(30 * time.Second),
 		},
+		CustomizeDiff: resourceCustomizeDiffHTMLContent,
 	}
 }
 
@@ -290,8 +288,13 @@ func resourceMyrasecMaintenanceTemplateImport(ctx context.Context, d *schema.Res
 
 // buildMaintenanceTemplate ...
 func buildMaintenanceTemplate(d *schema.ResourceData) (*myrasec.MaintenanceTemplate, error) {
+	content, err := renderHTMLContent(d.Get("content").(string), d.Get("content_source_dir").(string))
+	if err != nil {
+		return nil, err
+	}
+
 	template := &myrasec.MaintenanceTemplate{
-		Content: d.Get("content").(string),
+		Content: content,
 		Name:    d.Get("name").(string),
 	}
 
@@ -321,6 +324,20 @@ func buildMaintenanceTemplate(d *schema.ResourceData) (*myrasec.MaintenanceTempl
 
 // findMaintenanceTemplate ...
 func findMaintenanceTemplate(maintenanceTemplateID int, meta any, domainID int) (*myrasec.MaintenanceTemplate, diag.Diagnostics) {
+	return findMaintenanceTemplateBy(meta, domainID, func(mt *myrasec.MaintenanceTemplate) bool {
+		return mt.ID == maintenanceTemplateID
+	})
+}
+
+// findMaintenanceTemplateByName ...
+func findMaintenanceTemplateByName(name string, meta any, domainID int) (*myrasec.MaintenanceTemplate, diag.Diagnostics) {
+	return findMaintenanceTemplateBy(meta, domainID, func(mt *myrasec.MaintenanceTemplate) bool {
+		return mt.Name == name
+	})
+}
+
+// findMaintenanceTemplateBy returns the first maintenance template of the domain that matches
+func findMaintenanceTemplateBy(meta any, domainID int, match func(mt *myrasec.MaintenanceTemplate) bool) (*myrasec.MaintenanceTemplate, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	client := meta.(*myrasec.API)
@@ -345,7 +362,7 @@ func findMaintenanceTemplate(maintenanceTemplateID int, meta any, domainID int)
 		}
 
 		for _, mt := range res {
-			if mt.ID == maintenanceTemplateID {
+			if match(&mt) {
 				return &mt, diags
 			}
 		}
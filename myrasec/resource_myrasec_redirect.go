@@ -188,7 +188,7 @@ func resourceMyrasecRedirectRead(ctx context.Context, d *schema.ResourceData, me
 
 	setRedirectData(d, redirect, domainID)
 
-	return diags
+	return append(diags, redirectAnalysisWarnings(meta, redirect, subDomainName)...)
 }
 
 // resourceMyrasecRedirectUpdate ...
@@ -236,7 +236,7 @@ func resourceMyrasecRedirectUpdate(ctx context.Context, d *schema.ResourceData,
 
 	setRedirectData(d, redirect, domainID)
 
-	return diags
+	return append(diags, redirectAnalysisWarnings(meta, redirect, subDomainName)...)
 }
 
 // resourceMyrasecRedirectDelete ...
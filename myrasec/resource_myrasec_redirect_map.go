@@ -0,0 +1,652 @@
+package myrasec
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceMyrasecRedirectMap ...
+func resourceMyrasecRedirectMap() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecRedirectMapCreate,
+		ReadContext:   resourceMyrasecRedirectMapRead,
+		UpdateContext: resourceMyrasecRedirectMapUpdate,
+		DeleteContext: resourceMyrasecRedirectMapDelete,
+		Schema: map[string]*schema.Schema{
+			"subdomain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					name := i.(string)
+					if myrasec.IsGeneralDomainName(name) {
+						return name
+					}
+					return strings.ToLower(name)
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return myrasec.RemoveTrailingDot(old) == myrasec.RemoveTrailingDot(new)
+				},
+				Description: "The Subdomain for the redirects.",
+			},
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Stores domain Id for subdomain.",
+			},
+			"redirect": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Description:  "List of redirects.",
+				ExactlyOneOf: []string{"redirect", "redirects_csv", "redirects_json"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Location to match against.",
+						},
+						"destination": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Target where redirect should point to.",
+						},
+						"matching_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "exact",
+							ValidateFunc: validation.StringInSlice([]string{"exact", "prefix", "suffix"}, false),
+							Description:  "Type to match the redirect.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "permanent",
+							ValidateFunc: validation.StringInSlice([]string{"permanent", "redirect"}, false),
+							Description:  "Type of redirection.",
+						},
+						"expert_mode": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Disable redirect loop detection.",
+						},
+					},
+				},
+			},
+			"redirects_csv": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Redirects as CSV with the columns source, destination, matching_type, type and expert_mode.",
+				ExactlyOneOf: []string{"redirect", "redirects_csv", "redirects_json"},
+			},
+			"redirects_json": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Redirects as JSON array of objects with the keys source, destination, matching_type, type and expert_mode.",
+				ExactlyOneOf: []string{"redirect", "redirects_csv", "redirects_json"},
+			},
+			"sort_offset": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Sort of the first redirect. The following redirects are sorted in the order of the list.",
+			},
+			"allow_chains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow redirects to a destination that is redirected again.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Adopt existing redirects of the subdomain with the same matching type and source. Otherwise these redirects are reported as conflict.",
+			},
+			"entries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The redirects of the map.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"destination": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"matching_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"expert_mode": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"sort": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"redirect_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "IDs of the redirects by matching type and source.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		CustomizeDiff: resourceCustomizeDiffRedirectMap,
+	}
+}
+
+// redirectMapEntry is a single redirect of a redirect map
+type redirectMapEntry struct {
+	Source       string `json:"source"`
+	Destination  string `json:"destination"`
+	MatchingType string `json:"matching_type"`
+	Type         string `json:"type"`
+	ExpertMode   bool   `json:"expert_mode"`
+	Sort         int    `json:"-"`
+}
+
+// key identifies the redirect of the entry
+func (e *redirectMapEntry) key() string {
+	return e.MatchingType + ":" + e.Source
+}
+
+// resourceCustomizeDiffRedirectMap parses and validates the redirects
+func resourceCustomizeDiffRedirectMap(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	for _, k := range []string{"redirect", "redirects_csv", "redirects_json", "sort_offset", "allow_chains", "subdomain_name"} {
+		if !d.NewValueKnown(k) {
+			if err := d.SetNewComputed("entries"); err != nil {
+				return err
+			}
+			return d.SetNewComputed("redirect_ids")
+		}
+	}
+
+	entries, err := buildRedirectMapEntries(d.Get("redirect").([]any), d.Get("redirects_csv").(string), d.Get("redirects_json").(string), d.Get("sort_offset").(int))
+	if err != nil {
+		return err
+	}
+
+	if err := validateRedirectMap(entries, d.Get("subdomain_name").(string), d.Get("allow_chains").(bool)); err != nil {
+		return err
+	}
+
+	flattened := flattenRedirectMapEntries(entries)
+	current := d.Get("entries").([]any)
+	if d.Id() != "" && len(current) == len(flattened) {
+		changed := false
+		for i := range current {
+			if fmt.Sprint(current[i]) != fmt.Sprint(flattened[i]) {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+
+	if err := d.SetNew("entries", flattened); err != nil {
+		return err
+	}
+	return d.SetNewComputed("redirect_ids")
+}
+
+// resourceMyrasecRedirectMapCreate ...
+func resourceMyrasecRedirectMapCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	subDomainName := myrasec.RemoveTrailingDot(d.Get("subdomain_name").(string))
+	d.SetId(fmt.Sprintf("%s:%d", subDomainName, time.Now().Unix()))
+
+	diags := syncRedirectMap(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecRedirectMapRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecRedirectMapRead ...
+func resourceMyrasecRedirectMapRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listRedirectMapRedirects(meta, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	// redirects that were deleted outside of Terraform are removed from the state
+	ids := map[string]any{}
+	entries := []*redirectMapEntry{}
+	for _, id := range d.Get("redirect_ids").(map[string]any) {
+		r, ok := remote[id.(int)]
+		if !ok {
+			continue
+		}
+
+		entry := &redirectMapEntry{
+			Source:       r.Source,
+			Destination:  r.Destination,
+			MatchingType: r.MatchingType,
+			Type:         r.Type,
+			ExpertMode:   r.ExpertMode,
+			Sort:         r.Sort,
+		}
+		ids[entry.key()] = r.ID
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Sort == entries[j].Sort {
+			return entries[i].key() < entries[j].key()
+		}
+		return entries[i].Sort < entries[j].Sort
+	})
+
+	d.Set("entries", flattenRedirectMapEntries(entries))
+	d.Set("redirect_ids", ids)
+	d.Set("domain_id", domainID)
+
+	return diags
+}
+
+// resourceMyrasecRedirectMapUpdate ...
+func resourceMyrasecRedirectMapUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[INFO] Updating redirect map: %v", d.Id())
+
+	diags := syncRedirectMap(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecRedirectMapRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecRedirectMapDelete ...
+func resourceMyrasecRedirectMapDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	log.Printf("[INFO] Deleting redirect map: %v", d.Id())
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listRedirectMapRedirects(meta, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	for _, id := range d.Get("redirect_ids").(map[string]any) {
+		r, ok := remote[id.(int)]
+		if !ok {
+			continue
+		}
+
+		_, err := client.DeleteRedirect(r, domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting redirect",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// syncRedirectMap creates, updates and deletes the redirects to match the planned entries
+func syncRedirectMap(d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+	// the reads during the sync may still hit the cache, prune it so the Read that follows sees the changes
+	defer client.PruneCache()
+
+	domainID, subDomainName, diags := findSubdomainNameAndDomainID(d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	remote, diags := listRedirectMapRedirects(meta, subDomainName)
+	if diags.HasError() {
+		return diags
+	}
+
+	o, _ := d.GetChange("redirect_ids")
+	current := map[string]int{}
+	for k, id := range o.(map[string]any) {
+		if _, ok := remote[id.(int)]; ok {
+			current[k] = id.(int)
+		}
+	}
+
+	desired := map[string]*redirectMapEntry{}
+	var entries []*redirectMapEntry
+	for _, e := range d.Get("entries").([]any) {
+		entry := expandRedirectMapEntry(e.(map[string]any))
+		desired[entry.key()] = entry
+		entries = append(entries, entry)
+	}
+
+	// redirects of the subdomain that are not managed yet are only adopted by their source if adopt_existing is set,
+	// they may be managed by a myrasec_redirect resource or in the Myra UI
+	unmanaged := map[string]*myrasec.Redirect{}
+	managed := map[int]bool{}
+	for _, id := range o.(map[string]any) {
+		managed[id.(int)] = true
+	}
+	for _, r := range remote {
+		if !managed[r.ID] {
+			unmanaged[r.MatchingType+":"+r.Source] = r
+		}
+	}
+
+	if !d.Get("adopt_existing").(bool) {
+		conflicts := []string{}
+		for _, entry := range entries {
+			if r, ok := unmanaged[entry.key()]; ok {
+				if _, ok := current[entry.key()]; !ok {
+					conflicts = append(conflicts, fmt.Sprintf("%s (ID = %d)", entry.key(), r.ID))
+				}
+			}
+		}
+		if len(conflicts) > 0 {
+			sort.Strings(conflicts)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Redirects already exist",
+				Detail:   formatError(fmt.Errorf("the redirects [%s] of [%s] already exist and are not managed by this redirect map. Remove them or set adopt_existing = true to manage them with this redirect map", strings.Join(conflicts, ", "), subDomainName)),
+			})
+			return diags
+		}
+	}
+
+	// keep track of the managed redirects, also if an error occurs
+	defer func() {
+		ids := map[string]any{}
+		for k, id := range current {
+			ids[k] = id
+		}
+		d.Set("redirect_ids", ids)
+		d.Set("domain_id", domainID)
+	}()
+
+	for k, id := range current {
+		if _, ok := desired[k]; ok {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting redirect: %v", id)
+		_, err := client.DeleteRedirect(remote[id], domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error deleting redirect",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		delete(current, k)
+	}
+
+	for _, entry := range entries {
+		redirect := &myrasec.Redirect{
+			Type:          entry.Type,
+			MatchingType:  entry.MatchingType,
+			SubDomainName: subDomainName,
+			Source:        entry.Source,
+			Destination:   entry.Destination,
+			Sort:          entry.Sort,
+			Enabled:       true,
+			ExpertMode:    entry.ExpertMode,
+		}
+
+		var existing *myrasec.Redirect
+		if id, ok := current[entry.key()]; ok {
+			existing = remote[id]
+		} else if r, ok := unmanaged[entry.key()]; ok {
+			existing = r
+		}
+
+		if existing == nil {
+			resp, err := client.CreateRedirect(redirect, domainID, subDomainName)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Error creating redirect",
+					Detail:   formatError(fmt.Errorf("[%s]: %s", entry.Source, err.Error())),
+				})
+				return diags
+			}
+			current[entry.key()] = resp.ID
+			continue
+		}
+
+		current[entry.key()] = existing.ID
+		if existing.Destination == redirect.Destination && existing.Type == redirect.Type && existing.Sort == redirect.Sort &&
+			existing.ExpertMode == redirect.ExpertMode && existing.Enabled {
+			continue
+		}
+
+		redirect.ID = existing.ID
+		redirect.Created = existing.Created
+		redirect.Modified = existing.Modified
+		_, err := client.UpdateRedirect(redirect, domainID, subDomainName)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error updating redirect",
+				Detail:   formatError(fmt.Errorf("[%s]: %s", entry.Source, err.Error())),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// listRedirectMapRedirects returns all redirects of the subdomain by ID
+func listRedirectMapRedirects(meta any, subDomainName string) (map[int]*myrasec.Redirect, diag.Diagnostics) {
+	redirects, diags := listRedirects(meta, subDomainName, map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := map[int]*myrasec.Redirect{}
+	for i := range redirects {
+		result[redirects[i].ID] = &redirects[i]
+	}
+	return result, diags
+}
+
+// buildRedirectMapEntries parses the redirects from the list, the CSV or the JSON string
+func buildRedirectMapEntries(list []any, csvValue string, jsonValue string, sortOffset int) ([]*redirectMapEntry, error) {
+	var entries []*redirectMapEntry
+
+	switch {
+	case csvValue != "":
+		var err error
+		entries, err = parseRedirectMapCSV(csvValue)
+		if err != nil {
+			return nil, err
+		}
+	case jsonValue != "":
+		if err := json.Unmarshal([]byte(jsonValue), &entries); err != nil {
+			return nil, fmt.Errorf("unable to parse redirects_json: %s", err.Error())
+		}
+	default:
+		for _, item := range list {
+			if item == nil {
+				continue
+			}
+			entries = append(entries, expandRedirectMapEntry(item.(map[string]any)))
+		}
+	}
+
+	for i, entry := range entries {
+		if entry == nil {
+			return nil, fmt.Errorf("redirect #%d is empty", i+1)
+		}
+		if entry.MatchingType == "" {
+			entry.MatchingType = "exact"
+		}
+		if entry.Type == "" {
+			entry.Type = "permanent"
+		}
+		if entry.Source == "" || entry.Destination == "" {
+			return nil, fmt.Errorf("redirect #%d requires a source and a destination", i+1)
+		}
+		if !StringInSlice(entry.MatchingType, []string{"exact", "prefix", "suffix"}) {
+			return nil, fmt.Errorf("redirect #%d [%s] has an invalid matching_type [%s]", i+1, entry.Source, entry.MatchingType)
+		}
+		if !StringInSlice(entry.Type, []string{"permanent", "redirect"}) {
+			return nil, fmt.Errorf("redirect #%d [%s] has an invalid type [%s]", i+1, entry.Source, entry.Type)
+		}
+		entry.Sort = sortOffset + i
+	}
+
+	return entries, nil
+}
+
+// parseRedirectMapCSV parses the CSV rows source, destination, matching_type, type and expert_mode. A header row is skipped
+func parseRedirectMapCSV(value string) ([]*redirectMapEntry, error) {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse redirects_csv: %s", err.Error())
+	}
+
+	var entries []*redirectMapEntry
+	for i, record := range records {
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "source") {
+			continue
+		}
+		if len(record) < 2 || len(record) > 5 {
+			return nil, fmt.Errorf("line %d of redirects_csv must have between 2 and 5 columns", i+1)
+		}
+
+		entry := &redirectMapEntry{
+			Source:      strings.TrimSpace(record[0]),
+			Destination: strings.TrimSpace(record[1]),
+		}
+		if len(record) > 2 {
+			entry.MatchingType = strings.TrimSpace(record[2])
+		}
+		if len(record) > 3 {
+			entry.Type = strings.TrimSpace(record[3])
+		}
+		if len(record) > 4 && strings.TrimSpace(record[4]) != "" {
+			entry.ExpertMode, err = strconv.ParseBool(strings.TrimSpace(record[4]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d of redirects_csv has an invalid expert_mode [%s]", i+1, record[4])
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// validateRedirectMap checks for duplicate sources, redirect loops and chains
+func validateRedirectMap(entries []*redirectMapEntry, subDomainName string, allowChains bool) error {
+	keys := map[string]int{}
+	for i, entry := range entries {
+		if j, ok := keys[entry.key()]; ok {
+			return fmt.Errorf("duplicate source [%s] (%s) in redirect #%d and #%d", entry.Source, entry.MatchingType, j+1, i+1)
+		}
+		keys[entry.key()] = i
+	}
+
+	redirects := make([]myrasec.Redirect, len(entries))
+	for i, entry := range entries {
+		redirects[i] = myrasec.Redirect{
+			ID:           i + 1,
+			Source:       entry.Source,
+			Destination:  entry.Destination,
+			MatchingType: entry.MatchingType,
+			Sort:         entry.Sort,
+			Enabled:      true,
+			ExpertMode:   entry.ExpertMode,
+		}
+	}
+
+	for _, f := range analyzeRedirects(redirects, subDomainName) {
+		switch {
+		case f.Type == redirectFindingLoop:
+			return fmt.Errorf("redirect loop detected: %s", strings.Join(f.Path, " -> "))
+		case f.Type == redirectFindingChain && !allowChains:
+			return fmt.Errorf("redirect chain detected: %s. Redirect to the final destination or set allow_chains = true", strings.Join(f.Path, " -> "))
+		}
+	}
+
+	return nil
+}
+
+// expandRedirectMapEntry ...
+func expandRedirectMapEntry(m map[string]any) *redirectMapEntry {
+	entry := &redirectMapEntry{
+		Source:       m["source"].(string),
+		Destination:  m["destination"].(string),
+		MatchingType: m["matching_type"].(string),
+		Type:         m["type"].(string),
+		ExpertMode:   m["expert_mode"].(bool),
+	}
+	if s, ok := m["sort"]; ok {
+		entry.Sort = s.(int)
+	}
+	return entry
+}
+
+// flattenRedirectMapEntries ...
+func flattenRedirectMapEntries(entries []*redirectMapEntry) []any {
+	result := []any{}
+	for _, entry := range entries {
+		result = append(result, map[string]any{
+			"source":        entry.Source,
+			"destination":   entry.Destination,
+			"matching_type": entry.MatchingType,
+			"type":          entry.Type,
+			"expert_mode":   entry.ExpertMode,
+			"sort":          entry.Sort,
+		})
+	}
+	return result
+}
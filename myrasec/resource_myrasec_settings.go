@@ -5,18 +5,21 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
-	ClientMaxBodySize = 5120
+	ClientMaxBodySize    = 5120
+	HSTSPreloadMinMaxAge = 31536000
 )
 
 var diffieHellmanExchangeValues = []int{1024, 2048, 4096}
@@ -32,6 +35,26 @@ func resourceMyrasecSettings() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		SchemaVersion: 1,
+		Schema:        addAuthoritativeSettings(addSettingsGroups(resourceMyrasecSettingsV0().Schema)),
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceMyrasecSettingsV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceMyrasecSettingsStateUpgradeV0,
+				Version: 0,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+		CustomizeDiff: resourceCustomizeDiffSettings,
+	}
+}
+
+// resourceMyrasecSettingsV0 returns the flat settings schema (schema version 0)
+func resourceMyrasecSettingsV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"subdomain_name": {
 				Type:     schema.TypeString,
@@ -470,11 +493,6 @@ func resourceMyrasecSettings() *schema.Resource {
 				},
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(30 * time.Second),
-			Update: schema.DefaultTimeout(30 * time.Second),
-		},
-		CustomizeDiff: resourceCustomizeDiffSettings,
 	}
 }
 
@@ -483,7 +501,7 @@ func resourceCustomizeDiffSettings(ctx context.Context, d *schema.ResourceDiff,
 	availableAttributes := []string{}
 	resource := resourceMyrasecSettings()
 	for name, attr := range resource.Schema {
-		if name == "domain_id" || name == "subdomain_name" || name == "available_attributes" {
+		if name == "domain_id" || name == "subdomain_name" || isSettingsMetaAttribute(name) || isSettingsGroup(name) {
 			continue
 		}
 
@@ -501,9 +519,21 @@ func resourceCustomizeDiffSettings(ctx context.Context, d *schema.ResourceDiff,
 			availableAttributes = append(availableAttributes, name)
 		}
 	}
+	availableAttributes = append(availableAttributes, configuredSettingsGroupAttributes(d, resource.Schema)...)
 	d.SetNew("available_attributes", availableAttributes)
+	customizeDiffAuthoritativeSettings(d, availableAttributes)
+
+	err := validateSettingsGroups(d)
+	if err != nil {
+		return err
+	}
 
-	return validateCookieBasedName(d)
+	err = validateCookieBasedName(d)
+	if err != nil {
+		return err
+	}
+
+	return validateSettingsSSLCertificate(d, m)
 }
 
 func isNullValue(t *schema.Schema, d *schema.ResourceDiff, name string) bool {
@@ -605,6 +635,8 @@ func resourceMyrasecSettingsRead(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	setSettingsData(d, settings, subDomainName, domainID)
+	diags = append(diags, setUnmanagedSettingsData(d, extractSettingsMap(settings, "domain"))...)
+
 	clientMaxBodySize := d.Get("client_max_body_size")
 
 	if clientMaxBodySize.(int) > ClientMaxBodySize {
@@ -706,7 +738,7 @@ func buildSettings(d *schema.ResourceData, clean bool) (map[string]any, error) {
 
 	resource := resourceMyrasecSettings()
 	for name, attr := range resource.Schema {
-		if name == "domain_id" || name == "subdomain_name" || name == "available_attributes" {
+		if name == "domain_id" || name == "subdomain_name" || isSettingsMetaAttribute(name) || isSettingsGroup(name) {
 			continue
 		}
 		value, ok := d.GetOk(name)
@@ -727,35 +759,15 @@ func buildSettings(d *schema.ResourceData, clean bool) (map[string]any, error) {
 			}
 		}
 		if ok && !clean {
-			switch attr.Type {
-			case schema.TypeBool:
-				settingsMap[name] = value.(bool)
-			case schema.TypeInt:
-				settingsMap[name] = value.(int)
-			case schema.TypeString:
-				if value.(string) != "" {
-					settingsMap[name] = value.(string)
-				} else {
-					settingsMap[name] = nil
-				}
-			case schema.TypeList:
-				settingsList := []string{}
-				for _, item := range value.([]any) {
-					settingsList = append(settingsList, item.(string))
-				}
-				settingsMap[name] = settingsList
-			case schema.TypeSet:
-				settingsList := []string{}
-				for _, v := range value.(*schema.Set).List() {
-					settingsList = append(settingsList, v.(string))
-				}
-				settingsMap[name] = settingsList
-			}
+			settingsMap[name] = buildSettingsValue(attr, value)
 		} else {
 			settingsMap[name] = nil
 		}
 	}
 
+	buildSettingsGroups(d, resource.Schema, settingsMap, clean)
+	buildUnmanagedSettings(d, settingsMap)
+
 	return settingsMap, nil
 }
 
@@ -765,10 +777,11 @@ func setSettingsData(d *schema.ResourceData, settingsData any, subDomainName str
 	d.Set("domain_id", domainID)
 
 	resource := resourceMyrasecSettings().Schema
+	blockGroups := settingsGroupsInState(d)
 
 	// reset attributes befor setting them
 	for name := range resource {
-		if name == "domain_id" || name == "subdomain_name" || name == "available_attributes" {
+		if name == "domain_id" || name == "subdomain_name" || isSettingsMetaAttribute(name) {
 			continue
 		}
 		d.Set(name, nil)
@@ -778,22 +791,32 @@ func setSettingsData(d *schema.ResourceData, settingsData any, subDomainName str
 	domainSettings := (*allSettings)["domain"]
 
 	availableAttributes := []string{}
+	groupValues := map[string]map[string]any{}
 	mapSettings, ok := domainSettings.(map[string]any)
 	if ok {
 		for k, v := range mapSettings {
 			if k == "proxy_host_header" && mapSettings["host_header"] == nil {
 				k = "host_header"
 			}
-			if _, ok := resource[k]; !ok {
+			if group, attribute := findSettingsGroup(k); group != nil && blockGroups[group.Name] {
+				if v != nil {
+					if _, ok := groupValues[group.Name]; !ok {
+						groupValues[group.Name] = map[string]any{}
+					}
+					groupValues[group.Name][attribute] = v
+				}
+			} else if _, ok := resource[k]; ok {
+				d.Set(k, v)
+			} else {
 				continue
 			}
-			d.Set(k, v)
 			doAppend := appendAvailableAttributes(v, k, resource)
 			if doAppend {
 				availableAttributes = append(availableAttributes, k)
 			}
 		}
 	}
+	setSettingsGroupsData(d, groupValues)
 	d.Set("available_attributes", availableAttributes)
 
 	method := d.Get("balancing_method")
@@ -805,13 +828,13 @@ func setSettingsData(d *schema.ResourceData, settingsData any, subDomainName str
 func appendAvailableAttributes(v any, k string, resource map[string]*schema.Schema) bool {
 	append := false
 
-	if _, ok := resource[k]; !ok {
-		return append
+	if group, _ := findSettingsGroup(k); group == nil {
+		if _, ok := resource[k]; !ok {
+			return append
+		}
 	}
 	if _, ok := v.(bool); ok {
-		if _, ok := resource[k]; ok {
-			append = true
-		}
+		append = true
 	}
 	if _, ok := v.(int); ok {
 		append = true
@@ -832,9 +855,12 @@ func appendAvailableAttributes(v any, k string, resource map[string]*schema.Sche
 	return append
 }
 
-func validateCookieBasedName(d *schema.ResourceDiff) error {
-	method := d.Get("balancing_method")
-	cookie := d.Get("cookie_name")
+func validateCookieBasedName(d settingsReader) error {
+	method, _ := getSettingsValue(d, "balancing_method")
+	cookie, ok := getSettingsValue(d, "cookie_name")
+	if !ok {
+		cookie = ""
+	}
 	if method == "cookie_based" {
 		if cookie == "" {
 			return fmt.Errorf("cookie_name is required when balancing_method is cookie_based")
@@ -849,3 +875,672 @@ func validateCookieBasedName(d *schema.ResourceDiff) error {
 
 	return nil
 }
+
+// settingsGroup describes a nested block that groups related settings
+type settingsGroup struct {
+	Name        string
+	Description string
+	// Flat defines if the grouped settings are also available as flat attributes
+	Flat bool
+	// Attributes maps the attribute names inside of the block to the setting names
+	Attributes map[string]string
+}
+
+var settingsGroups = []settingsGroup{
+	{
+		Name:        "hsts",
+		Description: "HTTP Strict Transport Security (HSTS) settings.",
+		Flat:        false,
+		Attributes: map[string]string{
+			"enabled":            "hsts",
+			"include_subdomains": "hsts_include_subdomains",
+			"max_age":            "hsts_max_age",
+			"preload":            "hsts_preload",
+		},
+	},
+	{
+		Name:        "antibot",
+		Description: "Settings for the JavaScript based bot detection.",
+		Flat:        true,
+		Attributes: map[string]string{
+			"post_flood":              "antibot_post_flood",
+			"post_flood_threshold":    "antibot_post_flood_threshold",
+			"proof_of_work":           "antibot_proof_of_work",
+			"proof_of_work_threshold": "antibot_proof_of_work_threshold",
+		},
+	},
+	{
+		Name:        "request_limit",
+		Description: "Settings for the request limiter.",
+		Flat:        true,
+		Attributes: map[string]string{
+			"block":        "request_limit_block",
+			"level":        "request_limit_level",
+			"report":       "request_limit_report",
+			"report_email": "request_limit_report_email",
+		},
+	},
+	{
+		Name:        "origin",
+		Description: "Settings for the communication with the origin (upstream) servers.",
+		Flat:        true,
+		Attributes: map[string]string{
+			"balancing_method":         "balancing_method",
+			"cookie_name":              "cookie_name",
+			"enable_origin_sni":        "enable_origin_sni",
+			"http_origin_port":         "http_origin_port",
+			"next_upstream":            "next_upstream",
+			"only_https":               "only_https",
+			"origin_connection_header": "origin_connection_header",
+			"proxy_connect_timeout":    "proxy_connect_timeout",
+			"proxy_read_timeout":       "proxy_read_timeout",
+			"source_protocol":          "source_protocol",
+			"ssl_origin_port":          "ssl_origin_port",
+		},
+	},
+	{
+		Name:        "tls",
+		Description: "TLS and client certificate settings.",
+		Flat:        true,
+		Attributes: map[string]string{
+			"client_certificate":         "ssl_client_certificate",
+			"client_header_fingerprint":  "ssl_client_header_fingerprint",
+			"client_header_verification": "ssl_client_header_verification",
+			"client_verify":              "ssl_client_verify",
+			"diffie_hellman_exchange":    "diffie_hellman_exchange",
+			"limit_tls_version":          "limit_tls_version",
+			"myra_ssl_certificate":       "myra_ssl_certificate",
+			"myra_ssl_certificate_key":   "myra_ssl_certificate_key",
+			"myra_ssl_header":            "myra_ssl_header",
+		},
+	},
+	{
+		Name:        "cache",
+		Description: "Cache settings.",
+		Flat:        true,
+		Attributes: map[string]string{
+			"enabled":        "cache_enabled",
+			"bypass_cookie":  "proxy_cache_bypass",
+			"enforce_ttl":    "enforce_cache_ttl",
+			"ignore_nocache": "ignore_nocache",
+			"revalidate":     "cache_revalidate",
+			"stale":          "proxy_cache_stale",
+		},
+	},
+	{
+		Name:        "monitoring",
+		Description: "Settings for the upstream error reporting.",
+		Flat:        true,
+		Attributes: map[string]string{
+			"alert_threshold": "monitoring_alert_threshold",
+			"contact_email":   "monitoring_contact_email",
+			"send_alert":      "monitoring_send_alert",
+		},
+	},
+	{
+		Name:        "waf",
+		Description: "Web Application Firewall settings.",
+		Flat:        true,
+		Attributes: map[string]string{
+			"enabled": "waf_enable",
+			"levels":  "waf_levels_enable",
+			"policy":  "waf_policy",
+		},
+	},
+}
+
+// settingsReader is implemented by schema.ResourceData and schema.ResourceDiff
+type settingsReader interface {
+	Get(key string) any
+	GetRawConfig() cty.Value
+}
+
+// addSettingsGroups adds the nested settings blocks to the passed (flat) settings schema
+func addSettingsGroups(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, group := range settingsGroups {
+		attributes := map[string]*schema.Schema{}
+		for attribute, name := range group.Attributes {
+			attr := *s[name]
+			attributes[attribute] = &attr
+
+			if group.Flat {
+				s[name].ConflictsWith = []string{group.Name}
+			} else {
+				delete(s, name)
+			}
+		}
+
+		s[group.Name] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: attributes,
+			},
+			Description: group.Description,
+		}
+	}
+
+	return s
+}
+
+// addAuthoritativeSettings adds the attributes of the authoritative mode to the passed settings schema
+func addAuthoritativeSettings(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["authoritative"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Treat the configuration as the complete set of settings. Settings that are not configured are reported as drift and reset to the inherited values.",
+	}
+	s["managed_settings"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "The settings that are managed by the configuration (only in authoritative mode).",
+	}
+	s["unmanaged_settings"] = &schema.Schema{
+		Type:     schema.TypeMap,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "Settings that were changed outside of Terraform and will be reset on the next apply (only in authoritative mode).",
+	}
+
+	return s
+}
+
+// isSettingsMetaAttribute checks if the passed name is an attribute of the resource and not a setting
+func isSettingsMetaAttribute(name string) bool {
+	switch name {
+	case "available_attributes", "authoritative", "managed_settings", "unmanaged_settings":
+		return true
+	}
+	return false
+}
+
+// customizeDiffAuthoritativeSettings stores the managed settings and plans the reset of the unmanaged settings
+func customizeDiffAuthoritativeSettings(d *schema.ResourceDiff, availableAttributes []string) {
+	if !d.Get("authoritative").(bool) {
+		return
+	}
+
+	d.SetNew("managed_settings", availableAttributes)
+	if len(d.Get("unmanaged_settings").(map[string]any)) > 0 {
+		d.SetNew("unmanaged_settings", map[string]any{})
+	}
+}
+
+// buildUnmanagedSettings resets the unmanaged settings, including settings that are not part of the schema
+func buildUnmanagedSettings(d *schema.ResourceData, settingsMap map[string]any) {
+	unmanaged, _ := d.GetChange("unmanaged_settings")
+	for name := range unmanaged.(map[string]any) {
+		if _, ok := settingsMap[name]; !ok {
+			settingsMap[name] = nil
+		}
+	}
+}
+
+// setUnmanagedSettingsData stores the settings of the passed layer that are not managed by the configuration and reports them as drift
+func setUnmanagedSettingsData(d *schema.ResourceData, layer map[string]any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !d.Get("authoritative").(bool) {
+		d.Set("unmanaged_settings", nil)
+		return diags
+	}
+
+	managed := d.Get("managed_settings").(*schema.Set)
+	unmanaged := map[string]any{}
+	drift := []string{}
+	for name, value := range layer {
+		if value == nil || managed.Contains(name) {
+			continue
+		}
+		if name == "proxy_host_header" && managed.Contains("host_header") {
+			continue
+		}
+		unmanaged[name] = formatSettingsValue(value)
+		drift = append(drift, fmt.Sprintf("%s = %s", name, unmanaged[name]))
+	}
+	d.Set("unmanaged_settings", unmanaged)
+
+	if len(drift) > 0 {
+		sort.Strings(drift)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Settings changed outside of Terraform",
+			Detail:   fmt.Sprintf("The following settings are not part of the configuration and will be reset to the inherited values: %s", strings.Join(drift, ", ")),
+		})
+	}
+
+	return diags
+}
+
+// resourceMyrasecSettingsStateUpgradeV0 moves the flat settings that are only available as nested block into this block
+func resourceMyrasecSettingsStateUpgradeV0(ctx context.Context, rawState map[string]any, meta any) (map[string]any, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for _, group := range settingsGroups {
+		if group.Flat {
+			continue
+		}
+
+		block := map[string]any{}
+		for attribute, name := range group.Attributes {
+			value, ok := rawState[name]
+			if !ok {
+				continue
+			}
+			delete(rawState, name)
+			if value != nil {
+				block[attribute] = value
+			}
+		}
+
+		if len(block) > 0 {
+			rawState[group.Name] = []any{block}
+		}
+	}
+
+	return rawState, nil
+}
+
+// isSettingsGroup checks if the passed name is the name of a settings group block
+func isSettingsGroup(name string) bool {
+	for _, group := range settingsGroups {
+		if group.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// findSettingsGroup returns the settings group and the attribute name inside of the block for the passed setting name
+func findSettingsGroup(name string) (*settingsGroup, string) {
+	for i, group := range settingsGroups {
+		for attribute, n := range group.Attributes {
+			if n == name {
+				return &settingsGroups[i], attribute
+			}
+		}
+	}
+	return nil, ""
+}
+
+// rawSettingsGroupConfig returns the raw configuration of the passed settings group block
+func rawSettingsGroupConfig(d settingsReader, name string) (cty.Value, bool) {
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return cty.NilVal, false
+	}
+
+	block := raw.GetAttr(name)
+	if block.IsNull() || !block.IsKnown() || block.LengthInt() == 0 {
+		return cty.NilVal, false
+	}
+
+	return block.Index(cty.NumberIntVal(0)), true
+}
+
+// rawSettingsValue returns the configured (raw) value for the passed setting name, either from the nested block or from the flat attribute
+func rawSettingsValue(d settingsReader, name string) cty.Value {
+	group, attribute := findSettingsGroup(name)
+	if group != nil {
+		block, ok := rawSettingsGroupConfig(d, group.Name)
+		if ok {
+			return block.GetAttr(attribute)
+		}
+
+		if !group.Flat {
+			return cty.NullVal(cty.DynamicPseudoType)
+		}
+	}
+
+	raw := d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	return raw.GetAttr(name)
+}
+
+// isSettingsValueSet checks if the passed setting is configured with a non empty value. Values that are not known yet are treated as set.
+func isSettingsValueSet(d settingsReader, name string) bool {
+	raw := rawSettingsValue(d, name)
+	if raw.IsNull() {
+		return false
+	}
+	if !raw.IsWhollyKnown() {
+		return true
+	}
+
+	value, _ := getSettingsValue(d, name)
+	switch v := value.(type) {
+	case string:
+		return v != ""
+	case *schema.Set:
+		return v.Len() > 0
+	case []any:
+		return len(v) > 0
+	}
+	return value != nil
+}
+
+// getSettingsValue returns the configured value for the passed setting name, either from the nested block or from the flat attribute
+func getSettingsValue(d settingsReader, name string) (any, bool) {
+	group, attribute := findSettingsGroup(name)
+	if group != nil {
+		block, ok := rawSettingsGroupConfig(d, group.Name)
+		if ok {
+			if block.GetAttr(attribute).IsNull() {
+				return nil, false
+			}
+			return d.Get(fmt.Sprintf("%s.0.%s", group.Name, attribute)), true
+		}
+
+		if !group.Flat {
+			return nil, false
+		}
+	}
+
+	raw := d.GetRawConfig()
+	if raw.IsNull() || raw.GetAttr(name).IsNull() {
+		return nil, false
+	}
+
+	return d.Get(name), true
+}
+
+// configuredSettingsGroupAttributes returns the setting names of all configured attributes in the settings group blocks
+func configuredSettingsGroupAttributes(d *schema.ResourceDiff, s map[string]*schema.Schema) []string {
+	availableAttributes := []string{}
+
+	for _, group := range settingsGroups {
+		block, ok := rawSettingsGroupConfig(d, group.Name)
+		if !ok {
+			continue
+		}
+
+		elem := s[group.Name].Elem.(*schema.Resource).Schema
+		for attribute, name := range group.Attributes {
+			if block.GetAttr(attribute).IsNull() {
+				continue
+			}
+
+			value := d.Get(fmt.Sprintf("%s.0.%s", group.Name, attribute))
+			switch elem[attribute].Type {
+			case schema.TypeSet:
+				if value.(*schema.Set).Len() == 0 {
+					continue
+				}
+			case schema.TypeString:
+				if value.(string) == "" {
+					continue
+				}
+			}
+			availableAttributes = append(availableAttributes, name)
+		}
+	}
+
+	return availableAttributes
+}
+
+// buildSettingsGroups adds the values of the settings group blocks to the passed settings map
+func buildSettingsGroups(d *schema.ResourceData, s map[string]*schema.Schema, settingsMap map[string]any, clean bool) {
+	for _, group := range settingsGroups {
+		block, ok := rawSettingsGroupConfig(d, group.Name)
+		elem := s[group.Name].Elem.(*schema.Resource).Schema
+
+		for attribute, name := range group.Attributes {
+			if ok && !clean && !block.GetAttr(attribute).IsNull() {
+				settingsMap[name] = buildSettingsValue(elem[attribute], d.Get(fmt.Sprintf("%s.0.%s", group.Name, attribute)))
+				continue
+			}
+
+			if _, ok := settingsMap[name]; !ok || clean {
+				settingsMap[name] = nil
+			}
+		}
+	}
+}
+
+// buildSettingsValue converts the passed attribute value to the value that is sent to the API
+func buildSettingsValue(attr *schema.Schema, value any) any {
+	switch attr.Type {
+	case schema.TypeBool:
+		return value.(bool)
+	case schema.TypeInt:
+		return value.(int)
+	case schema.TypeString:
+		if value.(string) != "" {
+			return value.(string)
+		}
+		return nil
+	case schema.TypeList:
+		settingsList := []string{}
+		for _, item := range value.([]any) {
+			settingsList = append(settingsList, item.(string))
+		}
+		return settingsList
+	case schema.TypeSet:
+		settingsList := []string{}
+		for _, v := range value.(*schema.Set).List() {
+			settingsList = append(settingsList, v.(string))
+		}
+		return settingsList
+	}
+	return nil
+}
+
+// settingsGroupsInState returns the names of the settings groups that are managed as nested block
+func settingsGroupsInState(d *schema.ResourceData) map[string]bool {
+	groups := map[string]bool{}
+	for _, group := range settingsGroups {
+		if !group.Flat || len(d.Get(group.Name).([]any)) > 0 {
+			groups[group.Name] = true
+		}
+	}
+	return groups
+}
+
+// setSettingsGroupsData stores the collected values of the settings groups as nested blocks
+func setSettingsGroupsData(d *schema.ResourceData, groupValues map[string]map[string]any) {
+	origin, ok := groupValues["origin"]
+	if ok && origin["balancing_method"] != "cookie_based" {
+		origin["cookie_name"] = ""
+	}
+
+	for _, group := range settingsGroups {
+		values, ok := groupValues[group.Name]
+		if !ok {
+			d.Set(group.Name, nil)
+			continue
+		}
+		d.Set(group.Name, []any{values})
+	}
+}
+
+// validateSettingsGroups runs the cross-field validations of the grouped settings
+func validateSettingsGroups(d settingsReader) error {
+	validations := []func(d settingsReader) error{
+		validateHSTSSettings,
+		validateAntibotSettings,
+		validateRequestLimitSettings,
+		validateMonitoringSettings,
+		validateClientVerifySettings,
+	}
+
+	for _, validate := range validations {
+		if err := validate(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateHSTSSettings ...
+func validateHSTSSettings(d settingsReader) error {
+	preload, ok := getSettingsValue(d, "hsts_preload")
+	if !ok || !preload.(bool) {
+		return nil
+	}
+
+	if enabled, ok := getSettingsValue(d, "hsts"); ok && !enabled.(bool) {
+		return fmt.Errorf("hsts: preload requires enabled to be true")
+	}
+
+	includeSubdomains, ok := getSettingsValue(d, "hsts_include_subdomains")
+	if !ok || !includeSubdomains.(bool) {
+		return fmt.Errorf("hsts: preload requires include_subdomains to be true")
+	}
+
+	maxAge, ok := getSettingsValue(d, "hsts_max_age")
+	if !ok || maxAge.(int) < HSTSPreloadMinMaxAge {
+		return fmt.Errorf("hsts: preload requires a max_age of at least %d", HSTSPreloadMinMaxAge)
+	}
+
+	return nil
+}
+
+// validateAntibotSettings ...
+func validateAntibotSettings(d settingsReader) error {
+	checks := [][2]string{
+		{"antibot_post_flood_threshold", "antibot_post_flood"},
+		{"antibot_proof_of_work_threshold", "antibot_proof_of_work"},
+	}
+
+	for _, c := range checks {
+		threshold, check := c[0], c[1]
+		if _, ok := getSettingsValue(d, threshold); !ok {
+			continue
+		}
+
+		enabled, ok := getSettingsValue(d, check)
+		if ok && !enabled.(bool) {
+			_, thresholdAttribute := findSettingsGroup(threshold)
+			_, checkAttribute := findSettingsGroup(check)
+			return fmt.Errorf("antibot: %s is only allowed when %s is true", thresholdAttribute, checkAttribute)
+		}
+	}
+
+	return nil
+}
+
+// validateRequestLimitSettings ...
+func validateRequestLimitSettings(d settingsReader) error {
+	report, ok := getSettingsValue(d, "request_limit_report")
+	if !ok || !report.(bool) {
+		return nil
+	}
+
+	if !isSettingsValueSet(d, "request_limit_report_email") {
+		return fmt.Errorf("request_limit: report requires report_email to be set")
+	}
+
+	return nil
+}
+
+// validateMonitoringSettings ...
+func validateMonitoringSettings(d settingsReader) error {
+	sendAlert, ok := getSettingsValue(d, "monitoring_send_alert")
+	if !ok || !sendAlert.(bool) {
+		return nil
+	}
+
+	if !isSettingsValueSet(d, "monitoring_contact_email") {
+		return fmt.Errorf("monitoring: send_alert requires contact_email to be set")
+	}
+
+	return nil
+}
+
+// validateClientVerifySettings ...
+func validateClientVerifySettings(d settingsReader) error {
+	verify, ok := getSettingsValue(d, "ssl_client_verify")
+	if !ok || (verify != "on" && verify != "optional") {
+		return nil
+	}
+
+	if !isSettingsValueSet(d, "ssl_client_certificate") {
+		return fmt.Errorf("tls: client_verify %q requires client_certificate to be set", verify)
+	}
+
+	return nil
+}
+
+// validateSettingsSSLCertificate checks the settings that depend on the SSL certificate of the subdomain.
+// only_https requires a certificate covering the subdomain and limit_tls_version must be supported by the SSL configuration of this certificate.
+func validateSettingsSSLCertificate(d *schema.ResourceDiff, meta any) error {
+	if !d.NewValueKnown("subdomain_name") {
+		return nil
+	}
+
+	subDomainName := d.Get("subdomain_name").(string)
+	if myrasec.IsGeneralDomainName(subDomainName) {
+		return nil
+	}
+
+	if d.Id() != "" && !d.HasChanges("subdomain_name", "only_https", "limit_tls_version", "origin", "tls") {
+		return nil
+	}
+
+	onlyHTTPS := false
+	if value, ok := getSettingsValue(d, "only_https"); ok {
+		onlyHTTPS = value.(bool)
+	}
+
+	versions := []string{}
+	if rawSettingsValue(d, "limit_tls_version").IsWhollyKnown() {
+		if value, ok := getSettingsValue(d, "limit_tls_version"); ok {
+			for _, v := range value.(*schema.Set).List() {
+				versions = append(versions, v.(string))
+			}
+		}
+	}
+
+	if !onlyHTTPS && len(versions) == 0 {
+		return nil
+	}
+
+	domain, diags := findDomainBySubdomainName(meta, subDomainName)
+	if diags.HasError() {
+		return fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	certificates, diags := listSSLCertificates(meta, domain.Name, map[string]string{})
+	if diags.HasError() {
+		return fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+
+	cert := findSSLCertificateForSubdomain(certificates, subDomainName)
+	if cert == nil {
+		if onlyHTTPS {
+			return fmt.Errorf("only_https is enabled, but there is no SSL certificate covering [%s]", subDomainName)
+		}
+		return nil
+	}
+
+	if len(versions) == 0 || cert.SslConfigurationName == "" {
+		return nil
+	}
+
+	configuration, diags := findSslConfiguration(meta, cert.SslConfigurationName)
+	if diags.HasError() {
+		return fmt.Errorf("%s: %s", diags[0].Summary, diags[0].Detail)
+	}
+	if configuration == nil {
+		return nil
+	}
+
+	unsupported := unsupportedTLSVersions(configuration, versions)
+	if len(unsupported) > 0 {
+		return fmt.Errorf("limit_tls_version contains %s, which is not supported by the SSL configuration [%s] of the certificate [%d] (supported protocols: %s)", strings.Join(unsupported, ", "), configuration.Name, cert.ID, configuration.Protocols)
+	}
+
+	return nil
+}
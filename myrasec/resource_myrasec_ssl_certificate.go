@@ -1,14 +1,19 @@
 package myrasec
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,8 +21,11 @@ import (
 	"github.com/Myra-Security-GmbH/myrasec-go/v2"
 	"github.com/Myra-Security-GmbH/myrasec-go/v2/pkg/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/youmark/pkcs8"
+	"software.sslmate.com/src/go-pkcs12"
 )
 
 func validateNotBlank(val any, key string) (ws []string, errors []error) {
@@ -65,17 +73,41 @@ func resourceMyrasecSSLCertificate() *schema.Resource {
 			},
 			"certificate": {
 				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Certificate",
-				ValidateFunc: validateNotBlank,
+				Optional:     true,
+				ExactlyOneOf: []string{"certificate", "pkcs12"},
+				RequiredWith: []string{"key"},
+				Description:  "Certificate, or a PEM bundle containing the certificate followed by the intermediate certificates",
+				ValidateFunc: validation.All(validateNotBlank, validateSSLCertificateBundle),
 			},
 			"key": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
 				Sensitive:    true,
-				Description:  "Unencrypted private key",
+				RequiredWith: []string{"certificate"},
+				Description:  "Private key (PKCS1, PKCS8 or EC). Encrypted keys require the key_passphrase",
 				ValidateFunc: validateNotBlank,
 			},
+			"key_passphrase": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"key"},
+				Description:  "Passphrase of the encrypted private key",
+			},
+			"pkcs12": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"certificate", "key", "intermediate"},
+				Description:   "Base64 encoded PKCS#12 (.pfx) bundle containing the certificate, the private key and the intermediates",
+			},
+			"pkcs12_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"pkcs12"},
+				Description:  "Password of the PKCS#12 bundle",
+			},
 			"subject": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -152,18 +184,25 @@ func resourceMyrasecSSLCertificate() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"certificate": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Certificate",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Certificate",
+							ValidateFunc: validateSSLIntermediateCertificate,
 						},
 					},
 				},
 			},
-			"configuration_name": {
-				Type:         schema.TypeString,
+			"expiry_warning_days": {
+				Type:         schema.TypeInt,
 				Optional:     true,
-				Description:  "Set specific ssl configuration for ciphers and protocols",
-				ValidateFunc: validation.StringInSlice([]string{"Myra-Global-TLS-Default", "2023-mozilla-intermediate", "2023-mozilla-modern"}, true),
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Emit a warning when the certificate expires within the given number of days.",
+			},
+			"configuration_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Set specific ssl configuration for ciphers and protocols",
 				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
 					return newValue == "" || strings.EqualFold(oldValue, newValue)
 				},
@@ -173,65 +212,194 @@ func resourceMyrasecSSLCertificate() *schema.Resource {
 			Create: schema.DefaultTimeout(30 * time.Second),
 			Update: schema.DefaultTimeout(30 * time.Second),
 		},
-		CustomizeDiff: func(ctx context.Context, rd *schema.ResourceDiff, i any) error {
-			certificate := rd.Get("certificate")
-			privateKey := rd.Get("key")
+		CustomizeDiff: resourceCustomizeDiffSSLCertificate,
+	}
+}
 
-			certBlock, _ := pem.Decode([]byte(certificate.(string)))
-			if certBlock == nil {
-				return errors.New("Failed to decode PEM block for certificate")
-			}
+// resourceCustomizeDiffSSLCertificate checks that the private key matches the certificate and covers the subdomains
+func resourceCustomizeDiffSSLCertificate(ctx context.Context, rd *schema.ResourceDiff, i any) error {
+	err := validateSSLConfigurationName(rd, i)
+	if err != nil {
+		return err
+	}
 
-			cert, err := x509.ParseCertificate(certBlock.Bytes)
-			if err != nil {
-				return errors.New(formatError(err))
-			}
+	for _, name := range []string{"certificate", "key", "key_passphrase", "intermediate", "pkcs12", "pkcs12_password"} {
+		if !rd.NewValueKnown(name) {
+			return nil
+		}
+	}
 
-			keyBlock, _ := pem.Decode([]byte(privateKey.(string)))
-			if keyBlock == nil {
-				return fmt.Errorf("failed to decode PEM block for private key")
-			}
+	input, err := parseSSLCertificateInput(rd)
+	if err != nil {
+		return err
+	}
 
-			switch keyBlock.Type {
-			case "RSA PRIVATE KEY":
-				fallthrough
-			case "PRIVATE KEY":
-				privateKey, err = x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
-				if err != nil {
-					privateKey, err = x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
-					if err != nil {
-						return fmt.Errorf("failed to parse PKCS8 private key: %v", err)
-					}
-				}
-			case "EC PRIVATE KEY":
-				privateKey, err = x509.ParseECPrivateKey(keyBlock.Bytes)
-				if err != nil {
-					return fmt.Errorf("failed to parse EC private key: %v", err)
-				}
-			default:
-				return fmt.Errorf("unsupported private key format: %s", keyBlock.Type)
-			}
+	err = validateSSLCertificateKey(input.certificate, input.privateKey)
+	if err != nil {
+		return err
+	}
 
-			switch pub := cert.PublicKey.(type) {
-			case *rsa.PublicKey:
-				if priv, ok := privateKey.(*rsa.PrivateKey); ok {
-					if pub.N.Cmp(priv.N) == 0 && pub.E == priv.E {
-						return nil
-					}
-				}
-			case *ecdsa.PublicKey:
-				if priv, ok := privateKey.(*ecdsa.PrivateKey); ok {
-					if pub.X.Cmp(priv.X) == 0 && pub.Y.Cmp(priv.Y) == 0 && pub.Curve == priv.Curve {
-						return nil
-					}
-				}
-			default:
-				return fmt.Errorf("unsupported public key type")
-			}
+	if rd.NewValueKnown("subdomains") {
+		return validateSSLCertificateCoverage(input.certificate, rd.Get("subdomains").(*schema.Set))
+	}
 
-			return fmt.Errorf("private key does not match the certificate's public key")
-		},
+	return nil
+}
+
+// sslCertificateReader is implemented by schema.ResourceData and schema.ResourceDiff
+type sslCertificateReader interface {
+	Get(key string) any
+}
+
+// sslCertificateInput contains the decoded certificate, private key and intermediates
+type sslCertificateInput struct {
+	certificate   *x509.Certificate
+	privateKey    any
+	intermediates []*x509.Certificate
+	// decoded is true if the certificate or the key had to be decoded (PKCS#12 or encrypted key) before the upload
+	decoded bool
+}
+
+// parseSSLCertificateInput decodes the certificate, the private key and the intermediates,
+// either from the PEM encoded certificate, key and intermediate attributes or from the PKCS#12 bundle
+func parseSSLCertificateInput(d sslCertificateReader) (*sslCertificateInput, error) {
+	bundle := strings.TrimSpace(d.Get("pkcs12").(string))
+	if bundle != "" {
+		data, err := base64.StdEncoding.DecodeString(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode pkcs12, the bundle has to be base64 encoded (e.g. using filebase64()): %v", err)
+		}
+
+		privateKey, cert, caCerts, err := pkcs12.DecodeChain(data, d.Get("pkcs12_password").(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode pkcs12: %v", err)
+		}
+
+		return &sslCertificateInput{
+			certificate:   cert,
+			privateKey:    privateKey,
+			intermediates: caCerts,
+			decoded:       true,
+		}, nil
+	}
+
+	certificate := d.Get("certificate").(string)
+	if strings.TrimSpace(certificate) == "" {
+		return nil, errors.New("either certificate and key or pkcs12 is required")
 	}
+
+	certs, err := parsePEMCertificates(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %v", err)
+	}
+
+	key := d.Get("key").(string)
+	if strings.TrimSpace(key) == "" {
+		return nil, errors.New("key is required when certificate is set")
+	}
+
+	passphrase := d.Get("key_passphrase").(string)
+	privateKey, err := parsePrivateKey(key, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	intermediates, err := parseSSLIntermediates(certificate, d.Get("intermediate").(*schema.Set))
+	if err != nil {
+		return nil, err
+	}
+
+	return &sslCertificateInput{
+		certificate:   certs[0],
+		privateKey:    privateKey,
+		intermediates: intermediates,
+		decoded:       passphrase != "",
+	}, nil
+}
+
+// parsePrivateKey decodes the PEM encoded (and optionally encrypted) private key
+func parsePrivateKey(key string, passphrase string) (any, error) {
+	keyBlock, _ := pem.Decode([]byte(key))
+	if keyBlock == nil {
+		return nil, fmt.Errorf("failed to decode PEM block for private key")
+	}
+
+	if keyBlock.Type == "ENCRYPTED PRIVATE KEY" {
+		if passphrase == "" {
+			return nil, fmt.Errorf("the private key is encrypted, key_passphrase is required")
+		}
+		privateKey, err := pkcs8.ParsePKCS8PrivateKey(keyBlock.Bytes, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt PKCS8 private key: %v", err)
+		}
+		return privateKey, nil
+	}
+
+	der := keyBlock.Bytes
+	// legacy encrypted PEM blocks (Proc-Type: 4,ENCRYPTED)
+	if x509.IsEncryptedPEMBlock(keyBlock) {
+		if passphrase == "" {
+			return nil, fmt.Errorf("the private key is encrypted, key_passphrase is required")
+		}
+		var err error
+		der, err = x509.DecryptPEMBlock(keyBlock, []byte(passphrase))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt private key: %v", err)
+		}
+	}
+
+	switch keyBlock.Type {
+	case "RSA PRIVATE KEY":
+		fallthrough
+	case "PRIVATE KEY":
+		privateKey, err := x509.ParsePKCS1PrivateKey(der)
+		if err == nil {
+			return privateKey, nil
+		}
+		pkcs8Key, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PKCS8 private key: %v", err)
+		}
+		return pkcs8Key, nil
+	case "EC PRIVATE KEY":
+		privateKey, err := x509.ParseECPrivateKey(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse EC private key: %v", err)
+		}
+		return privateKey, nil
+	}
+
+	return nil, fmt.Errorf("unsupported private key format: %s", keyBlock.Type)
+}
+
+// encodePrivateKey returns the unencrypted PKCS8 PEM encoded private key
+func encodePrivateKey(privateKey any) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// validateSSLCertificateKey checks that the passed private key matches the public key of the certificate (RSA, ECDSA and Ed25519)
+func validateSSLCertificateKey(cert *x509.Certificate, privateKey any) error {
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return fmt.Errorf("unsupported public key type")
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return fmt.Errorf("unsupported private key type")
+	}
+
+	pub, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if ok && pub.Equal(signer.Public()) {
+		return nil
+	}
+
+	return fmt.Errorf("private key does not match the certificate's public key")
 }
 
 // resourceMyrasecSSLCertificateCreate ...
@@ -249,6 +417,7 @@ func resourceMyrasecSSLCertificateCreate(ctx context.Context, d *schema.Resource
 		})
 		return diags
 	}
+	diags = append(diags, sslCertificateChainWarnings(d)...)
 
 	domainName := d.Get("domain_name").(string)
 
@@ -268,7 +437,7 @@ func resourceMyrasecSSLCertificateCreate(ctx context.Context, d *schema.Resource
 	}
 
 	d.SetId(fmt.Sprintf("%d", resp.ID))
-	return resourceMyrasecSSLCertificateRead(ctx, d, meta)
+	return append(diags, resourceMyrasecSSLCertificateRead(ctx, d, meta)...)
 }
 
 // resourceMyrasecSSLCertificateRead ...
@@ -308,6 +477,8 @@ func resourceMyrasecSSLCertificateRead(ctx context.Context, d *schema.ResourceDa
 
 	setSSLCertificateData(d, cert, domainName, domainID)
 
+	diags = append(diags, checkExpireDate(fmt.Sprintf("SSL certificate [%s]", cert.Subject), cert.ValidTo, d.Get("expiry_warning_days").(int), "Upload a renewed certificate.")...)
+
 	return diags
 }
 
@@ -338,6 +509,7 @@ func resourceMyrasecSSLCertificateUpdate(ctx context.Context, d *schema.Resource
 		})
 		return diags
 	}
+	diags = append(diags, sslCertificateChainWarnings(d)...)
 
 	domainName := d.Get("domain_name").(string)
 
@@ -346,17 +518,19 @@ func resourceMyrasecSSLCertificateUpdate(ctx context.Context, d *schema.Resource
 		return domainDiag
 	}
 
-	oldCert, newCert := d.GetChange("certificate")
-	oldKey, newKey := d.GetChange("key")
-
-	if oldCert == newCert && oldKey == newKey {
+	if !d.HasChanges("certificate", "key", "key_passphrase", "pkcs12", "pkcs12_password") {
 		log.Println("[INFO] Update certificate")
 		cert, err = client.UpdateSSLCertificate(cert, domainID)
 	} else if cert.ID > 0 {
-		log.Println("[INFO] Replace certificate")
-		cert.CertToRefresh = cert.ID
-		cert.ID = 0
-		cert, err = client.CreateSSLCertificate(cert, domainID)
+		log.Println("[INFO] Rotate certificate")
+		var rotateDiags diag.Diagnostics
+		cert, rotateDiags = rotateSSLCertificate(ctx, d, meta, cert, domainID)
+		diags = append(diags, rotateDiags...)
+		if cert == nil {
+			return diags
+		}
+		setSSLCertificateData(d, cert, domainName, domainID)
+		return diags
 	} else {
 		log.Println("[INFO] Create certificate")
 		cert.ID = 0
@@ -377,6 +551,101 @@ func resourceMyrasecSSLCertificateUpdate(ctx context.Context, d *schema.Resource
 	return diags
 }
 
+// rotateSSLCertificate uploads the new certificate as refresh of the current one, which moves the subdomain assignment to the new certificate.
+// The old certificate is only deleted after the new certificate is served for all subdomains.
+func rotateSSLCertificate(ctx context.Context, d *schema.ResourceData, meta any, cert *myrasec.SSLCertificate, domainID int) (*myrasec.SSLCertificate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	client := meta.(*myrasec.API)
+
+	oldID := cert.ID
+	cert.CertToRefresh = oldID
+	cert.ID = 0
+
+	created, err := client.CreateSSLCertificate(cert, domainID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error updating SSL certificate",
+			Detail:   formatError(err),
+		})
+		return nil, diags
+	}
+	log.Printf("[INFO] Uploaded SSL certificate [%d] as refresh of [%d]", created.ID, oldID)
+
+	served, err := waitForSSLCertificateSubdomains(ctx, client, domainID, created.ID, cert.Subdomains, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		d.SetId(strconv.Itoa(created.ID))
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error rotating SSL certificate",
+			Detail:   fmt.Sprintf("The new SSL certificate [%d] was uploaded, but it is not served for all subdomains: %s. The old SSL certificate [%d] was not deleted.", created.ID, formatError(err), oldID),
+		})
+		return nil, diags
+	}
+
+	old, err := client.GetSSLCertificate(domainID, oldID)
+	if isNotFoundError(err) || (err == nil && old == nil) {
+		log.Printf("[INFO] Old SSL certificate [%d] was already removed", oldID)
+		return served, diags
+	}
+	if err != nil {
+		d.SetId(strconv.Itoa(served.ID))
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error loading the old SSL certificate",
+			Detail:   fmt.Sprintf("The new SSL certificate [%d] is served for all subdomains, but the old SSL certificate [%d] could not be loaded and was not deleted: %s", served.ID, oldID, formatError(err)),
+		})
+		return served, diags
+	}
+
+	log.Printf("[INFO] Deleting old SSL certificate [%d]", oldID)
+	_, err = client.DeleteSSLCertificate(old, domainID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Error deleting the old SSL certificate",
+			Detail:   fmt.Sprintf("The SSL certificate was rotated, but the old SSL certificate [%d] could not be deleted: %s", oldID, formatError(err)),
+		})
+	}
+
+	return served, diags
+}
+
+// waitForSSLCertificateSubdomains waits until the certificate is assigned to all passed subdomains
+func waitForSSLCertificateSubdomains(ctx context.Context, client *myrasec.API, domainID int, certID int, subdomains []string, timeout time.Duration) (*myrasec.SSLCertificate, error) {
+	var cert *myrasec.SSLCertificate
+
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		c, err := client.GetSSLCertificate(domainID, certID)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+		if c == nil {
+			return retry.RetryableError(fmt.Errorf("SSL certificate [%d] not found", certID))
+		}
+
+		assigned := map[string]bool{}
+		for _, sd := range c.Subdomains {
+			assigned[strings.ToLower(myrasec.RemoveTrailingDot(sd))] = true
+		}
+		missing := []string{}
+		for _, sd := range subdomains {
+			if !assigned[strings.ToLower(myrasec.RemoveTrailingDot(sd))] {
+				missing = append(missing, sd)
+			}
+		}
+		if len(missing) > 0 {
+			return retry.RetryableError(fmt.Errorf("missing subdomains [%s]", strings.Join(missing, ", ")))
+		}
+
+		cert = c
+		return nil
+	})
+
+	return cert, err
+}
+
 // resourceMyrasecSSLCertificateDelete ...
 func resourceMyrasecSSLCertificateDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*myrasec.API)
@@ -464,9 +733,11 @@ func buildSSLCertificate(d *schema.ResourceData) (*myrasec.SSLCertificate, error
 		cert.Subdomains = append(cert.Subdomains, sd.(string))
 	}
 
+	input, inputErr := parseSSLCertificateInput(d)
+
 	crt, ok := d.GetOk("certificate")
 	if ok {
-		cert.Certificate.Cert = crt.(string)
+		cert.Certificate.Cert, _ = splitSSLCertificateBundle(crt.(string))
 	}
 
 	key, ok := d.GetOk("key")
@@ -474,6 +745,15 @@ func buildSSLCertificate(d *schema.ResourceData) (*myrasec.SSLCertificate, error
 		cert.Key = key.(string)
 	}
 
+	// PKCS#12 bundles and encrypted keys are uploaded as unencrypted PEM
+	if inputErr == nil && input.decoded {
+		cert.Certificate.Cert = encodePEMCertificate(input.certificate)
+		cert.Key, inputErr = encodePrivateKey(input.privateKey)
+		if inputErr != nil {
+			return nil, inputErr
+		}
+	}
+
 	ctr, ok := d.GetOk("cert_to_refresh")
 	if ok {
 		cert.CertToRefresh = ctr.(int)
@@ -510,13 +790,22 @@ func buildSSLCertificate(d *schema.ResourceData) (*myrasec.SSLCertificate, error
 	}
 	cert.Certificate.Modified = modified
 
-	intermediates, ok := d.GetOk("intermediate")
-	if !ok {
+	if inputErr != nil {
+		for _, intermediate := range d.Get("intermediate").(*schema.Set).List() {
+			icert, err := buildSSLIntermediate(intermediate)
+			if err != nil {
+				return nil, err
+			}
+
+			cert.Intermediates = append(cert.Intermediates, *icert)
+		}
 		return cert, nil
 	}
 
-	for _, intermediate := range intermediates.(*schema.Set).List() {
-		icert, err := buildSSLIntermediate(intermediate)
+	// upload the intermediates in the order of the chain
+	chain, unused := buildSSLCertificateChain(input.certificate, input.intermediates)
+	for _, c := range append(chain, unused...) {
+		icert, err := buildSSLIntermediate(map[string]any{"certificate": encodePEMCertificate(c)})
 		if err != nil {
 			return nil, err
 		}
@@ -586,3 +875,279 @@ func setSSLCertificateData(d *schema.ResourceData, cert *myrasec.SSLCertificate,
 	d.Set("domain_id", domainID)
 	d.Set("configuration_name", cert.SslConfigurationName)
 }
+
+// sslNameMatches checks if the passed host name is covered by the certificate name. Wildcard names only cover one label.
+func sslNameMatches(certName string, name string) bool {
+	certName = strings.ToLower(myrasec.RemoveTrailingDot(certName))
+	name = strings.ToLower(myrasec.RemoveTrailingDot(name))
+
+	if certName == name {
+		return true
+	}
+
+	if !strings.HasPrefix(certName, "*.") {
+		return false
+	}
+
+	suffix := certName[1:]
+	label := strings.TrimSuffix(name, suffix)
+	return strings.HasSuffix(name, suffix) && label != "" && !strings.Contains(label, ".")
+}
+
+// sslCertificateNames returns the names the certificate is valid for
+func sslCertificateNames(cert *x509.Certificate) []string {
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames
+	}
+	if cert.Subject.CommonName != "" {
+		return []string{cert.Subject.CommonName}
+	}
+	return []string{}
+}
+
+// validateSSLCertificateCoverage checks that the certificate covers all subdomains it should be assigned to
+func validateSSLCertificateCoverage(cert *x509.Certificate, subdomains *schema.Set) error {
+	names := sslCertificateNames(cert)
+
+	uncovered := []string{}
+	for _, sd := range subdomains.List() {
+		covered := false
+		for _, name := range names {
+			if sslNameMatches(name, sd.(string)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, sd.(string))
+		}
+	}
+
+	if len(uncovered) > 0 {
+		sort.Strings(uncovered)
+		return fmt.Errorf("the certificate does not cover the subdomains [%s], it is only valid for [%s]", strings.Join(uncovered, ", "), strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// findSSLCertificateForSubdomain returns the certificate that is assigned to or covers the passed subdomain
+func findSSLCertificateForSubdomain(certificates []myrasec.SSLCertificate, subDomainName string) *myrasec.SSLCertificate {
+	for i, cert := range certificates {
+		for _, s := range cert.Subdomains {
+			if sslNameMatches(s, subDomainName) {
+				return &certificates[i]
+			}
+		}
+	}
+
+	for i, cert := range certificates {
+		for _, san := range cert.SubjectAlternatives {
+			if sslNameMatches(san, subDomainName) {
+				return &certificates[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// parsePEMCertificates returns all certificates of the passed PEM encoded string
+func parsePEMCertificates(data string) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return certs, nil
+}
+
+// encodePEMCertificate returns the PEM encoded certificate
+func encodePEMCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// isSelfSignedCertificate checks if the passed certificate is self-signed (a root certificate or a self-signed leaf certificate)
+func isSelfSignedCertificate(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// validateSSLCertificateBundle validates the certificate and warns about root certificates in the bundle
+func validateSSLCertificateBundle(i any, k string) (ws []string, errs []error) {
+	value, ok := i.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return
+	}
+
+	certs, err := parsePEMCertificates(value)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s: %v", k, err))
+		return
+	}
+
+	for _, cert := range certs[1:] {
+		if isSelfSignedCertificate(cert) {
+			ws = append(ws, fmt.Sprintf("%s: the bundle contains the root certificate [%s]. Root certificates should not be part of the chain.", k, cert.Subject))
+		}
+	}
+
+	chain, unused := buildSSLCertificateChain(certs[0], certs[1:])
+	if len(unused) == 0 {
+		for i, cert := range chain {
+			if !cert.Equal(certs[i+1]) {
+				ws = append(ws, fmt.Sprintf("%s: the intermediate certificates of the bundle are not in the order of the chain, they are uploaded in the order of the chain starting with [%s].", k, chain[0].Subject))
+				break
+			}
+		}
+	}
+	return
+}
+
+// validateSSLIntermediateCertificate validates the intermediate certificate and warns if it is a root certificate
+func validateSSLIntermediateCertificate(i any, k string) (ws []string, errs []error) {
+	value, ok := i.(string)
+	if !ok || strings.TrimSpace(value) == "" {
+		return
+	}
+
+	certs, err := parsePEMCertificates(value)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s: %v", k, err))
+		return
+	}
+
+	for _, cert := range certs {
+		if isSelfSignedCertificate(cert) {
+			ws = append(ws, fmt.Sprintf("%s: [%s] is a root certificate. Root certificates should not be part of the chain.", k, cert.Subject))
+		}
+	}
+	return
+}
+
+// splitSSLCertificateBundle splits a PEM bundle into the leaf certificate and the PEM encoded intermediates
+func splitSSLCertificateBundle(bundle string) (string, []string) {
+	certs, err := parsePEMCertificates(bundle)
+	if err != nil || len(certs) < 2 {
+		return bundle, nil
+	}
+
+	intermediates := []string{}
+	for _, cert := range certs[1:] {
+		intermediates = append(intermediates, encodePEMCertificate(cert))
+	}
+
+	return encodePEMCertificate(certs[0]), intermediates
+}
+
+// parseSSLIntermediates returns the intermediates from the certificate bundle and the intermediate blocks, without duplicates
+func parseSSLIntermediates(bundle string, intermediates *schema.Set) ([]*x509.Certificate, error) {
+	result := []*x509.Certificate{}
+	seen := map[string]bool{}
+
+	add := func(data string) error {
+		certs, err := parsePEMCertificates(data)
+		if err != nil {
+			return err
+		}
+		for _, cert := range certs {
+			if seen[string(cert.Raw)] {
+				continue
+			}
+			seen[string(cert.Raw)] = true
+			result = append(result, cert)
+		}
+		return nil
+	}
+
+	if certs, err := parsePEMCertificates(bundle); err == nil {
+		seen[string(certs[0].Raw)] = true
+		for _, cert := range certs[1:] {
+			if err := add(encodePEMCertificate(cert)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, intermediate := range intermediates.List() {
+		data, _ := intermediate.(map[string]any)["certificate"].(string)
+		if strings.TrimSpace(data) == "" {
+			continue
+		}
+		if err := add(data); err != nil {
+			return nil, fmt.Errorf("failed to parse intermediate certificate: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// buildSSLCertificateChain orders the intermediates, starting with the issuer of the leaf certificate.
+// Intermediates that are not part of the chain are returned as unused.
+func buildSSLCertificateChain(leaf *x509.Certificate, intermediates []*x509.Certificate) (chain []*x509.Certificate, unused []*x509.Certificate) {
+	unused = append(unused, intermediates...)
+
+	current := leaf
+	for len(unused) > 0 && !isSelfSignedCertificate(current) {
+		next := -1
+		for i, candidate := range unused {
+			if current.CheckSignatureFrom(candidate) == nil {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		current = unused[next]
+		chain = append(chain, current)
+		unused = append(unused[:next], unused[next+1:]...)
+	}
+
+	return chain, unused
+}
+
+// sslCertificateChainWarnings warns about intermediates that are not part of the certificate chain
+func sslCertificateChainWarnings(d sslCertificateReader) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	input, err := parseSSLCertificateInput(d)
+	if err != nil {
+		return diags
+	}
+
+	chain, unused := buildSSLCertificateChain(input.certificate, input.intermediates)
+	last := input.certificate
+	if len(chain) > 0 {
+		last = chain[len(chain)-1]
+	}
+
+	for _, cert := range unused {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Intermediate certificate is not part of the chain",
+			Detail:   fmt.Sprintf("The intermediate certificate [%s] does not sign [%s] or another certificate of the chain. It is uploaded after the certificates of the chain.", cert.Subject, last.Subject),
+		})
+	}
+
+	return diags
+}
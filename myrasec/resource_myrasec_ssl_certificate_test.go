@@ -0,0 +1,299 @@
+package myrasec
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/Myra-Security-GmbH/myrasec-go/v2/pkg/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// sslCertificateStandIn is a local stand-in of the SSL certificate endpoints of the Myra API
+type sslCertificateStandIn struct {
+	mu           sync.Mutex
+	server       *httptest.Server
+	certificates map[int]*myrasec.SSLCertificate
+	nextID       int
+	// getStatus overrides the response status of GetSSLCertificate for the certificate ID
+	getStatus map[int]int
+	created   []myrasec.SSLCertificate
+	updated   []myrasec.SSLCertificate
+}
+
+var (
+	sslCertificatePath  = regexp.MustCompile(`^/domain/1/ssl/certificates/(\d+)$`)
+	certificateSavePath = regexp.MustCompile(`^/domain/1/certificates(?:/(\d+))?$`)
+)
+
+// newSSLCertificateStandIn starts the stand-in, it is stopped when the test finishes
+func newSSLCertificateStandIn(t *testing.T) *sslCertificateStandIn {
+	s := &sslCertificateStandIn{
+		certificates: map[int]*myrasec.SSLCertificate{},
+		nextID:       1,
+		getStatus:    map[int]int{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// client returns an API client that sends the requests to the stand-in
+func (s *sslCertificateStandIn) client(t *testing.T) *myrasec.API {
+	client, err := Config{
+		APIKey:     "key",
+		Secret:     "secret",
+		Language:   "en",
+		APIBaseURL: s.server.URL + "/%s",
+	}.Client()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func (s *sslCertificateStandIn) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/domains":
+		writeStandInResponse(w, http.StatusOK, map[string]any{
+			"list": []any{myrasec.Domain{ID: 1, Name: "example.com"}},
+		})
+
+	case r.Method == http.MethodGet && sslCertificatePath.MatchString(r.URL.Path):
+		id, _ := strconv.Atoi(sslCertificatePath.FindStringSubmatch(r.URL.Path)[1])
+		if status, ok := s.getStatus[id]; ok {
+			writeStandInResponse(w, status, map[string]any{"error": true, "errorMessage": http.StatusText(status)})
+			return
+		}
+		cert, ok := s.certificates[id]
+		if !ok {
+			writeStandInResponse(w, http.StatusNotFound, map[string]any{"error": true, "errorMessage": "Not found"})
+			return
+		}
+		writeStandInResponse(w, http.StatusOK, map[string]any{"data": []any{cert}})
+
+	case r.Method == http.MethodPost && certificateSavePath.MatchString(r.URL.Path):
+		var cert myrasec.SSLCertificate
+		if err := json.NewDecoder(r.Body).Decode(&cert); err != nil {
+			writeStandInResponse(w, http.StatusBadRequest, map[string]any{"error": true, "errorMessage": err.Error()})
+			return
+		}
+		s.created = append(s.created, cert)
+
+		cert.Certificate = standInCertificateDetails(s.nextID, cert.Cert)
+		cert.Key = ""
+		s.nextID++
+
+		// a refresh moves the subdomains of the refreshed certificate to the new one
+		if old, ok := s.certificates[cert.CertToRefresh]; ok {
+			cert.Subdomains = old.Subdomains
+			old.Subdomains = []string{}
+		}
+		s.certificates[cert.ID] = &cert
+		writeStandInResponse(w, http.StatusOK, map[string]any{"targetObject": []any{cert}})
+
+	case r.Method == http.MethodPut && certificateSavePath.MatchString(r.URL.Path):
+		id, _ := strconv.Atoi(certificateSavePath.FindStringSubmatch(r.URL.Path)[1])
+		cert, ok := s.certificates[id]
+		if !ok {
+			writeStandInResponse(w, http.StatusNotFound, map[string]any{"error": true, "errorMessage": "Not found"})
+			return
+		}
+		var update myrasec.SSLCertificate
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			writeStandInResponse(w, http.StatusBadRequest, map[string]any{"error": true, "errorMessage": err.Error()})
+			return
+		}
+		s.updated = append(s.updated, update)
+
+		cert.Subdomains = update.Subdomains
+		writeStandInResponse(w, http.StatusOK, map[string]any{"targetObject": []any{cert}})
+
+	default:
+		writeStandInResponse(w, http.StatusNotImplemented, map[string]any{"error": true, "errorMessage": r.Method + " " + r.URL.Path})
+	}
+}
+
+// standInCertificateDetails returns the details the API extracts from an uploaded certificate
+func standInCertificateDetails(id int, certificate string) *myrasec.Certificate {
+	details := &myrasec.Certificate{
+		ID:       id,
+		Created:  types.DateTimeNow(),
+		Modified: types.DateTimeNow(),
+		Cert:     certificate,
+	}
+
+	certs, err := parsePEMCertificates(certificate)
+	if err == nil {
+		details.Subject = certs[0].Subject.String()
+		details.SerialNumber = certs[0].SerialNumber.String()
+		details.ValidFrom = &types.DateTime{Time: certs[0].NotBefore}
+		details.ValidTo = &types.DateTime{Time: certs[0].NotAfter}
+	}
+	return details
+}
+
+func writeStandInResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// generateSSLCertificate returns a PEM encoded self-signed certificate and its private key for the passed names
+func generateSSLCertificate(t *testing.T, names ...string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: names[0]},
+		DNSNames:              names,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+// applySSLCertificateConfig plans and applies the passed configuration like terraform apply does
+func applySSLCertificateConfig(t *testing.T, client *myrasec.API, state *terraform.InstanceState, config map[string]any) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	resource := resourceMyrasecSSLCertificate()
+	c := terraform.NewResourceConfigRaw(config)
+	if diags := resource.Validate(c); diags.HasError() {
+		t.Fatalf("invalid configuration: %v", diags)
+	}
+
+	diff, err := resource.Diff(context.Background(), state, c, client)
+	if err != nil {
+		t.Fatalf("plan failed: %v", err)
+	}
+
+	return resource.Apply(context.Background(), state, diff, client)
+}
+
+// createRotatedSSLCertificate creates a certificate and plans the rotation to a new certificate
+func createRotatedSSLCertificate(t *testing.T, s *sslCertificateStandIn) (*terraform.InstanceState, map[string]any) {
+	client := s.client(t)
+
+	cert, key := generateSSLCertificate(t, "www.example.com")
+	config := map[string]any{
+		"domain_name": "example.com",
+		"certificate": cert,
+		"key":         key,
+		"subdomains":  []any{"www.example.com"},
+	}
+
+	state, diags := applySSLCertificateConfig(t, client, nil, config)
+	if diags.HasError() {
+		t.Fatalf("create failed: %v", diags)
+	}
+	if state.ID != "1" {
+		t.Fatalf("expected the certificate [1] to be created, got [%s]", state.ID)
+	}
+
+	cert, key = generateSSLCertificate(t, "www.example.com")
+	config["certificate"] = cert
+	config["key"] = key
+
+	return state, config
+}
+
+// TestSSLCertificateRotation checks that a changed certificate is uploaded as refresh of the current one,
+// that the subdomains are served by the new certificate and that the old certificate is deleted afterwards
+func TestSSLCertificateRotation(t *testing.T) {
+	s := newSSLCertificateStandIn(t)
+	state, config := createRotatedSSLCertificate(t, s)
+
+	state, diags := applySSLCertificateConfig(t, s.client(t), state, config)
+	if diags.HasError() {
+		t.Fatalf("rotation failed: %v", diags)
+	}
+
+	if state.ID != "2" {
+		t.Errorf("expected the state to track the new certificate [2], got [%s]", state.ID)
+	}
+	if len(s.created) != 2 || s.created[1].CertToRefresh != 1 {
+		t.Errorf("expected the new certificate to be uploaded as refresh of [1], got %+v", s.created)
+	}
+	if subdomains := s.certificates[2].Subdomains; len(subdomains) != 1 || subdomains[0] != "www.example.com" {
+		t.Errorf("expected the new certificate to be served for [www.example.com], got %v", subdomains)
+	}
+	if len(s.updated) != 1 || s.updated[0].ID != 1 || len(s.updated[0].Subdomains) != 0 {
+		t.Errorf("expected the old certificate [1] to be deleted, got %+v", s.updated)
+	}
+}
+
+// TestSSLCertificateRotationOldCertificateRemoved checks that the rotation succeeds if the old certificate no longer exists
+func TestSSLCertificateRotationOldCertificateRemoved(t *testing.T) {
+	s := newSSLCertificateStandIn(t)
+	state, config := createRotatedSSLCertificate(t, s)
+
+	s.getStatus[1] = http.StatusNotFound
+
+	state, diags := applySSLCertificateConfig(t, s.client(t), state, config)
+	if diags.HasError() {
+		t.Fatalf("rotation failed: %v", diags)
+	}
+
+	if state.ID != "2" {
+		t.Errorf("expected the state to track the new certificate [2], got [%s]", state.ID)
+	}
+	if len(s.updated) != 0 {
+		t.Errorf("expected no delete of the removed certificate, got %+v", s.updated)
+	}
+}
+
+// TestSSLCertificateRotationOldCertificateError checks that an error loading the old certificate is reported
+// and that the old certificate is not treated as removed
+func TestSSLCertificateRotationOldCertificateError(t *testing.T) {
+	s := newSSLCertificateStandIn(t)
+	state, config := createRotatedSSLCertificate(t, s)
+
+	s.getStatus[1] = http.StatusForbidden
+
+	state, diags := applySSLCertificateConfig(t, s.client(t), state, config)
+	if !diags.HasError() {
+		t.Fatal("expected an error loading the old certificate")
+	}
+
+	if state == nil || state.ID != "2" {
+		t.Errorf("expected the state to track the new certificate [2], got %v", state)
+	}
+	if len(s.updated) != 0 {
+		t.Errorf("expected the old certificate to be kept, got %+v", s.updated)
+	}
+}
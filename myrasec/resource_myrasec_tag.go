@@ -68,6 +68,12 @@ func resourceMyrasecTag() *schema.Resource {
 				Computed:    true,
 				Description: "Identifies global tags",
 			},
+			"manage_assignments": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Manage the assignments of the tag with the assignments blocks. Defaults to true if assignments blocks are configured, otherwise the assignments are kept for myrasec_tag_assignment resources.",
+			},
 			"assignments": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -142,6 +148,33 @@ func resourceMyrasecTag() *schema.Resource {
 			Create: schema.DefaultTimeout(30 * time.Second),
 			Update: schema.DefaultTimeout(30 * time.Second),
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, i any) error {
+			if !d.NewValueKnown("assignments") {
+				return nil
+			}
+
+			configured := d.NewValueKnown("manage_assignments")
+			if raw := d.GetRawConfig(); !raw.IsNull() {
+				configured = !raw.GetAttr("manage_assignments").IsNull()
+			}
+
+			// without manage_assignments the assignments are only managed if assignments blocks are configured,
+			// so a tag without assignments blocks does not remove the assignments of myrasec_tag_assignment resources
+			manage := d.Get("manage_assignments").(bool)
+			if !configured {
+				manage = d.Get("assignments").(*schema.Set).Len() > 0
+				if err := d.SetNew("manage_assignments", manage); err != nil {
+					return err
+				}
+			} else if !d.NewValueKnown("manage_assignments") {
+				return nil
+			}
+
+			if !manage && d.Get("assignments").(*schema.Set).Len() > 0 {
+				return fmt.Errorf("assignments blocks can not be used when manage_assignments is false")
+			}
+			return nil
+		},
 	}
 }
 
@@ -216,6 +249,23 @@ func resourceMyrasecTagUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diags
 	}
 
+	tagAssignmentLock.Lock()
+	defer tagAssignmentLock.Unlock()
+
+	// the assignments are managed by myrasec_tag_assignment resources, so the current ones are kept
+	if !d.Get("manage_assignments").(bool) {
+		current, err := client.GetTag(tag.ID)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error loading tag",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		tag.Assignments = current.Assignments
+	}
+
 	resp, err := client.UpdateTag(tag)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -273,6 +323,7 @@ func resourceMyrasecTagImport(ctx context.Context, d *schema.ResourceData, meta
 	}
 	d.SetId(strconv.Itoa(tagID))
 	d.Set("tag_id", tag.ID)
+	d.Set("manage_assignments", true)
 
 	resourceMyrasecTagRead(ctx, d, meta)
 	return []*schema.ResourceData{d}, nil
@@ -388,6 +439,11 @@ func setTagData(d *schema.ResourceData, tag *myrasec.Tag) {
 	d.Set("created", tag.Created.Format(time.RFC3339))
 	d.Set("modified", tag.Modified.Format(time.RFC3339))
 
+	if !d.Get("manage_assignments").(bool) {
+		d.Set("assignments", nil)
+		return
+	}
+
 	assignments := make([]any, 0)
 	for _, a := range tag.Assignments {
 		if a.SubDomainName == "" {
@@ -0,0 +1,291 @@
+package myrasec
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Tag assignments are stored as part of the tag, the lock prevents concurrent updates of the assignments from overwriting each other
+var tagAssignmentLock sync.Mutex
+
+// resourceMyrasecTagAssignment ...
+func resourceMyrasecTagAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecTagAssignmentCreate,
+		ReadContext:   resourceMyrasecTagAssignmentRead,
+		DeleteContext: resourceMyrasecTagAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMyrasecTagAssignmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"tag_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The Id of the tag for the assignment.",
+			},
+			"assignment_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the tag assignment.",
+			},
+			"modified": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date of last modification.",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date of creation.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					return strings.ToUpper(i.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+				ValidateFunc: validation.StringInSlice([]string{"DOMAIN", "SUBDOMAIN"}, true),
+				Description:  "The Type of the tag assignment",
+			},
+			"subdomain_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(i any) string {
+					return myrasec.RemoveTrailingDot(i.(string))
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(myrasec.RemoveTrailingDot(old), myrasec.RemoveTrailingDot(new))
+				},
+				Description: "The domain name or subdomain name depending on the type",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+	}
+}
+
+// resourceMyrasecTagAssignmentCreate ...
+func resourceMyrasecTagAssignmentCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	tagID := d.Get("tag_id").(int)
+	assignmentType := strings.ToUpper(d.Get("type").(string))
+	subDomainName := myrasec.RemoveTrailingDot(d.Get("subdomain_name").(string))
+
+	tagAssignmentLock.Lock()
+	defer tagAssignmentLock.Unlock()
+
+	tag, err := client.GetTag(tagID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error loading tag",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	if findTagAssignment(tag, assignmentType, subDomainName) != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Tag assignment already exists",
+			Detail: fmt.Sprintf(
+				"[%s] is already assigned to the tag [%d]. It might be managed by the assignments block of a myrasec_tag resource, "+
+					"which must not be combined with myrasec_tag_assignment for the same tag. Use [terraform import] with the ID [%d:%s] to manage the existing assignment.",
+				subDomainName, tagID, tagID, subDomainName,
+			),
+		})
+		return diags
+	}
+
+	tag.Assignments = append(tag.Assignments, myrasec.TagAssignment{
+		Type:          assignmentType,
+		Title:         subDomainName,
+		SubDomainName: subDomainName,
+	})
+
+	_, err = client.UpdateTag(tag)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error creating tag assignment",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", tagID, subDomainName))
+	return resourceMyrasecTagAssignmentRead(ctx, d, meta)
+}
+
+// resourceMyrasecTagAssignmentRead ...
+func resourceMyrasecTagAssignmentRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tagID, subDomainName, err := parseTagAssignmentID(d.Id())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error parsing tag assignment ID",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	tag, diags := findTag(tagID, meta)
+	if tag == nil {
+		d.SetId("")
+		return diags
+	}
+
+	assignment := findTagAssignment(tag, d.Get("type").(string), subDomainName)
+	if assignment == nil {
+		log.Printf("[WARN] Tag assignment [%s] not found, removing it from the state", d.Id())
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("tag_id", tagID)
+	d.Set("assignment_id", assignment.ID)
+	d.Set("type", assignment.Type)
+	d.Set("subdomain_name", myrasec.RemoveTrailingDot(assignment.SubDomainName))
+	if assignment.Created != nil {
+		d.Set("created", assignment.Created.Format(time.RFC3339))
+	}
+	if assignment.Modified != nil {
+		d.Set("modified", assignment.Modified.Format(time.RFC3339))
+	}
+
+	return diags
+}
+
+// resourceMyrasecTagAssignmentDelete ...
+func resourceMyrasecTagAssignmentDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	tagID, subDomainName, err := parseTagAssignmentID(d.Id())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error parsing tag assignment ID",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	tagAssignmentLock.Lock()
+	defer tagAssignmentLock.Unlock()
+
+	tag, err := client.GetTag(tagID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error loading tag",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	assignment := findTagAssignment(tag, d.Get("type").(string), subDomainName)
+	if assignment == nil {
+		return diags
+	}
+
+	assignments := make([]myrasec.TagAssignment, 0, len(tag.Assignments))
+	for _, a := range tag.Assignments {
+		if a.ID != assignment.ID {
+			assignments = append(assignments, a)
+		}
+	}
+	tag.Assignments = assignments
+
+	_, err = client.UpdateTag(tag)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error deleting tag assignment",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	return diags
+}
+
+// resourceMyrasecTagAssignmentImport ...
+func resourceMyrasecTagAssignmentImport(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+	tagID, subDomainName, err := parseTagAssignmentID(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error parsing tag assignment ID: [%s]", err.Error())
+	}
+
+	tag, diags := findTag(tagID, meta)
+	if diags.HasError() || tag == nil {
+		return nil, fmt.Errorf("unable to find tag with id [%d]", tagID)
+	}
+
+	if findTagAssignment(tag, "", subDomainName) == nil {
+		return nil, fmt.Errorf("unable to find assignment [%s] for tag with id [%d]", subDomainName, tagID)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", tagID, myrasec.RemoveTrailingDot(subDomainName)))
+	resourceMyrasecTagAssignmentRead(ctx, d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseTagAssignmentID splits the ID of a tag assignment ({TAG_ID}:{SUBDOMAIN_NAME}) into its parts
+func parseTagAssignmentID(id string) (int, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return 0, "", fmt.Errorf("unexpected format of ID (%s), expected tagID:subdomain", id)
+	}
+
+	tagID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("first part of ID is not an integer value (%s)", id)
+	}
+
+	return tagID, parts[1], nil
+}
+
+// findTagAssignment returns the assignment of the tag for the passed subdomain name. An empty assignment type matches any type.
+func findTagAssignment(tag *myrasec.Tag, assignmentType string, subDomainName string) *myrasec.TagAssignment {
+	for i, a := range tag.Assignments {
+		name := a.SubDomainName
+		if name == "" {
+			name = a.Title
+		}
+
+		if assignmentType != "" && !strings.EqualFold(a.Type, assignmentType) {
+			continue
+		}
+
+		if strings.EqualFold(myrasec.RemoveTrailingDot(name), myrasec.RemoveTrailingDot(subDomainName)) {
+			return &tag.Assignments[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,958 @@
+package myrasec
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	myrasec "github.com/Myra-Security-GmbH/myrasec-go/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// tagBundleTag describes one of the tags of a tag bundle
+type tagBundleTag struct {
+	attribute string
+	tagType   string
+}
+
+// tagBundleTags are the tags that are created for a tag bundle. The WAF tag comes first, its ID is the ID of the bundle.
+var tagBundleTags = []tagBundleTag{
+	{attribute: "waf_tag_id", tagType: "WAF"},
+	{attribute: "cache_tag_id", tagType: "CACHE"},
+	{attribute: "config_tag_id", tagType: "CONFIG"},
+	{attribute: "information_tag_id", tagType: "INFORMATION"},
+}
+
+// tagBundleChild describes a nested block of a tag bundle that is managed by the builders and setters of a single tag resource
+type tagBundleChild struct {
+	key       string
+	tagID     string
+	idKey     string
+	stateOnly []string
+	resource  func() *schema.Resource
+	create    func(meta any, d *schema.ResourceData, tagID int) (int, error)
+	update    func(meta any, d *schema.ResourceData, tagID int) error
+	delete    func(meta any, d *schema.ResourceData, tagID int) error
+	list      func(meta any, tagID int) ([]*schema.ResourceData, error)
+	// prepare sets the values of an item that are computed by the provider, it is called before the item is created or updated
+	prepare func(d *schema.ResourceData, prefix string, values map[string]any)
+}
+
+// tagBundleChildren returns the nested blocks of a tag bundle
+func tagBundleChildren() []tagBundleChild {
+	return []tagBundleChild{
+		{
+			key:       "waf_rule",
+			tagID:     "waf_tag_id",
+			idKey:     "rule_id",
+			stateOnly: []string{"expire_date", "template", "template_policy", "applied_hash"},
+			resource:  resourceMyrasecTagWAFRule,
+			prepare:   prepareTagBundleWAFRule,
+			create:    createTagBundleWAFRule,
+			update:    updateTagBundleWAFRule,
+			delete:    deleteTagBundleWAFRule,
+			list:      listTagBundleWAFRules,
+		},
+		{
+			key:      "cache_setting",
+			tagID:    "cache_tag_id",
+			idKey:    "setting_id",
+			resource: resourceMyrasecTagCacheSetting,
+			create:   createTagBundleCacheSetting,
+			update:   updateTagBundleCacheSetting,
+			delete:   deleteTagBundleCacheSetting,
+			list:     listTagBundleCacheSettings,
+		},
+		{
+			key:      "information",
+			tagID:    "information_tag_id",
+			idKey:    "information_id",
+			resource: resourceMyrasecTagInformation,
+			create:   createTagBundleInformation,
+			update:   updateTagBundleInformation,
+			delete:   deleteTagBundleInformation,
+			list:     listTagBundleInformation,
+		},
+	}
+}
+
+// resourceMyrasecTagBundle ...
+func resourceMyrasecTagBundle() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMyrasecTagBundleCreate,
+		ReadContext:   resourceMyrasecTagBundleRead,
+		UpdateContext: resourceMyrasecTagBundleUpdate,
+		DeleteContext: resourceMyrasecTagBundleDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the bundle. It is used as name for all tags of the bundle.",
+			},
+			"sort": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Order in which the WAF tag of the bundle is processed.",
+			},
+			"subdomains": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(i any) string {
+						return strings.ToLower(myrasec.RemoveTrailingDot(i.(string)))
+					},
+				},
+				Description: "List of subdomains the tags of the bundle are assigned to.",
+			},
+			"domains": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					StateFunc: func(i any) string {
+						return strings.ToLower(myrasec.RemoveTrailingDot(i.(string)))
+					},
+				},
+				Description: "List of domains the tags of the bundle are assigned to.",
+			},
+			"waf_tag_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the WAF tag.",
+			},
+			"cache_tag_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the CACHE tag.",
+			},
+			"config_tag_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the CONFIG tag.",
+			},
+			"information_tag_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID of the INFORMATION tag.",
+			},
+			"assignments_in_sync": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "False if the assignments of the tags differ from each other.",
+			},
+			"waf_rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: tagBundleBlockSchema(resourceMyrasecTagWAFRule()),
+				},
+				Description: "WAF rules of the WAF tag.",
+			},
+			"cache_setting": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: tagBundleBlockSchema(resourceMyrasecTagCacheSetting()),
+				},
+				Description: "Cache settings of the CACHE tag.",
+			},
+			"settings": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: tagBundleSettingsSchema(),
+				},
+				Description: "Settings of the CONFIG tag.",
+			},
+			"information": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: tagBundleBlockSchema(resourceMyrasecTagInformation()),
+				},
+				Description: "Information of the INFORMATION tag.",
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, i any) error {
+			settings := tagBundleSettingsReader{d: d}
+			if err := validateSettingsGroups(settings); err != nil {
+				return err
+			}
+			if err := validateCookieBasedName(settings); err != nil {
+				return err
+			}
+
+			if d.Id() != "" && !d.Get("assignments_in_sync").(bool) {
+				return d.SetNew("assignments_in_sync", true)
+			}
+			return nil
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Second),
+			Update: schema.DefaultTimeout(60 * time.Second),
+		},
+	}
+}
+
+// resourceMyrasecTagBundleCreate ...
+func resourceMyrasecTagBundleCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	for _, t := range tagBundleTags {
+		tag := &myrasec.Tag{
+			Name:        d.Get("name").(string),
+			Type:        t.tagType,
+			Assignments: buildTagBundleAssignments(d, nil),
+		}
+		if t.tagType == "WAF" {
+			tag.Sort = d.Get("sort").(int)
+		}
+
+		resp, err := client.CreateTag(tag)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error creating %s tag", t.tagType),
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+		d.Set(t.attribute, resp.ID)
+
+		// the ID is set as soon as the first tag exists, so a failed create can be cleaned up by a destroy
+		if d.Id() == "" {
+			d.SetId(strconv.Itoa(resp.ID))
+		}
+	}
+
+	diags = append(diags, syncTagBundle(d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecTagBundleRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecTagBundleRead ...
+func resourceMyrasecTagBundleRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tagID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error parsing tag bundle ID",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	wafTag, diags := findTag(tagID, meta)
+	if wafTag == nil {
+		d.SetId("")
+		return diags
+	}
+
+	subdomains, domains := flattenTagBundleAssignments(wafTag.Assignments)
+	inSync := true
+	for _, t := range tagBundleTags[1:] {
+		tag, tagDiags := findTag(d.Get(t.attribute).(int), meta)
+		if tag == nil {
+			// the bundle can not be refreshed without the tag, so an error loading the tag is not only a warning
+			for i := range tagDiags {
+				tagDiags[i].Severity = diag.Error
+			}
+			return append(diags, tagDiags...)
+		}
+
+		s, dn := flattenTagBundleAssignments(tag.Assignments)
+		if strings.Join(s, ",") != strings.Join(subdomains, ",") || strings.Join(dn, ",") != strings.Join(domains, ",") {
+			inSync = false
+		}
+	}
+
+	d.Set("waf_tag_id", wafTag.ID)
+	d.Set("name", wafTag.Name)
+	d.Set("sort", wafTag.Sort)
+	d.Set("subdomains", subdomains)
+	d.Set("domains", domains)
+	d.Set("assignments_in_sync", inSync)
+
+	for _, child := range tagBundleChildren() {
+		diags = append(diags, readTagBundleChildren(d, meta, child)...)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return append(diags, readTagBundleSettings(d, meta)...)
+}
+
+// resourceMyrasecTagBundleUpdate ...
+func resourceMyrasecTagBundleUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	if d.HasChanges("name", "sort", "subdomains", "domains", "assignments_in_sync") {
+		tagAssignmentLock.Lock()
+		defer tagAssignmentLock.Unlock()
+
+		for _, t := range tagBundleTags {
+			tag, err := client.GetTag(d.Get(t.attribute).(int))
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Error loading %s tag", t.tagType),
+					Detail:   formatError(err),
+				})
+				return diags
+			}
+
+			tag.Name = d.Get("name").(string)
+			tag.Assignments = buildTagBundleAssignments(d, tag)
+			if t.tagType == "WAF" {
+				tag.Sort = d.Get("sort").(int)
+			}
+
+			_, err = client.UpdateTag(tag)
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Error updating %s tag", t.tagType),
+					Detail:   formatError(err),
+				})
+				return diags
+			}
+		}
+	}
+
+	diags = append(diags, syncTagBundle(d, meta)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, resourceMyrasecTagBundleRead(ctx, d, meta)...)
+}
+
+// resourceMyrasecTagBundleDelete ...
+func resourceMyrasecTagBundleDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	for _, t := range tagBundleTags {
+		tagID := d.Get(t.attribute).(int)
+		if tagID <= 0 {
+			continue
+		}
+
+		log.Printf("[INFO] Deleting %s tag: %v", t.tagType, tagID)
+		_, err := client.DeleteTag(&myrasec.Tag{ID: tagID, Type: t.tagType})
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error deleting %s tag", t.tagType),
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// syncTagBundle creates, updates and deletes the WAF rules, cache settings, settings and information of the bundle
+func syncTagBundle(d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, child := range tagBundleChildren() {
+		err := syncTagBundleChildren(d, meta, child)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error updating " + child.key,
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	if d.HasChange("settings") {
+		settingsData, configured := tagBundleSettingsResourceData(d)
+		settings, err := buildTagSettings(settingsData, !configured)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error building tag settings",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+
+		client := meta.(*myrasec.API)
+		_, err = client.UpdateTagSettingsPartial(settings, d.Get("config_tag_id").(int))
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Error updating tag settings",
+				Detail:   formatError(err),
+			})
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// syncTagBundleChildren creates, updates and deletes the items of a nested block. Items are matched by their position in the list.
+// The IDs of the items are stored, so the Read keeps the items at their position.
+func syncTagBundleChildren(d *schema.ResourceData, meta any, child tagBundleChild) (err error) {
+	if !d.HasChange(child.key) {
+		return nil
+	}
+
+	tagID := d.Get(child.tagID).(int)
+	resource := child.resource()
+
+	o, n := d.GetChange(child.key)
+	oldItems := o.([]any)
+	newItems := n.([]any)
+
+	synced := []any{}
+	defer func() {
+		// items that are not synced because of an error are appended by the Read
+		for _, item := range synced {
+			delete(item.(map[string]any), "tag_id")
+		}
+		if setErr := d.Set(child.key, synced); err == nil {
+			err = setErr
+		}
+	}()
+
+	for i, item := range newItems {
+		values := item.(map[string]any)
+		values["tag_id"] = tagID
+		synced = append(synced, values)
+
+		if i < len(oldItems) {
+			old := oldItems[i].(map[string]any)
+			if id, ok := old[child.idKey].(int); ok && id > 0 {
+				values[child.idKey] = id
+				values["created"] = old["created"]
+				values["modified"] = old["modified"]
+
+				if !d.HasChange(fmt.Sprintf("%s.%d", child.key, i)) {
+					continue
+				}
+
+				if child.prepare != nil {
+					child.prepare(d, fmt.Sprintf("%s.%d.", child.key, i), values)
+				}
+
+				err := child.update(meta, tagBundleResourceData(resource, values), tagID)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		values[child.idKey] = 0
+		if child.prepare != nil {
+			child.prepare(d, fmt.Sprintf("%s.%d.", child.key, i), values)
+		}
+		id, err := child.create(meta, tagBundleResourceData(resource, values), tagID)
+		if err != nil {
+			synced = synced[:len(synced)-1]
+			return err
+		}
+		values[child.idKey] = id
+	}
+
+	for i := len(newItems); i < len(oldItems); i++ {
+		values := oldItems[i].(map[string]any)
+		if id, ok := values[child.idKey].(int); !ok || id <= 0 {
+			continue
+		}
+		values["tag_id"] = tagID
+
+		err := child.delete(meta, tagBundleResourceData(resource, values), tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTagBundleChildren sets the items of a nested block. Known items keep their position, new items are appended.
+func readTagBundleChildren(d *schema.ResourceData, meta any, child tagBundleChild) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	tagID := d.Get(child.tagID).(int)
+	items, err := child.list(meta, tagID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error loading " + child.key,
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	block := tagBundleBlockSchema(child.resource())
+	current := map[int]map[string]any{}
+	ids := []int{}
+	for _, item := range items {
+		values := tagBundleBlockValues(item, block)
+		id := values[child.idKey].(int)
+		current[id] = values
+		ids = append(ids, id)
+	}
+
+	result := []any{}
+	for _, item := range d.Get(child.key).([]any) {
+		old := item.(map[string]any)
+		id, _ := old[child.idKey].(int)
+		values, ok := current[id]
+		if !ok {
+			continue
+		}
+		for _, k := range child.stateOnly {
+			values[k] = old[k]
+		}
+		result = append(result, values)
+		delete(current, id)
+	}
+
+	for _, id := range ids {
+		if values, ok := current[id]; ok {
+			result = append(result, values)
+		}
+	}
+
+	if err := d.Set(child.key, result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// tagBundleSettingsSchema returns the schema of the tag settings resource without the tag_id and the meta attributes, to use it as settings block.
+// The conflicting settings groups are referenced by their path inside of the block.
+func tagBundleSettingsSchema() map[string]*schema.Schema {
+	block := tagBundleBlockSchema(resourceMyrasecTagSettings())
+	for name, attr := range block {
+		if isSettingsMetaAttribute(name) {
+			delete(block, name)
+			continue
+		}
+
+		if len(attr.ConflictsWith) > 0 {
+			a := *attr
+			a.ConflictsWith = []string{}
+			for _, key := range attr.ConflictsWith {
+				a.ConflictsWith = append(a.ConflictsWith, "settings.0."+key)
+			}
+			block[name] = &a
+		}
+	}
+	return block
+}
+
+// tagBundleSettingsResourceData returns a ResourceData of the tag settings resource that contains the values and the
+// configuration of the settings block, so buildTagSettings can be used for the block. The bool is false if the block is not configured.
+func tagBundleSettingsResourceData(d *schema.ResourceData) (*schema.ResourceData, bool) {
+	state := &terraform.InstanceState{}
+	raw := d.GetRawConfig()
+	if !raw.IsNull() && raw.IsKnown() {
+		block := raw.GetAttr("settings")
+		if block.IsKnown() && !block.IsNull() && block.LengthInt() > 0 {
+			state.RawConfig = block.Index(cty.NumberIntVal(0))
+		}
+	}
+
+	settings := resourceMyrasecTagSettings().Data(state)
+	if list := d.Get("settings").([]any); len(list) > 0 && list[0] != nil {
+		for name, value := range list[0].(map[string]any) {
+			settings.Set(name, value)
+		}
+	}
+
+	return settings, !state.RawConfig.IsNull()
+}
+
+// tagBundleSettingsReader reads the settings block of the tag bundle like the tag settings resource, so the validations
+// of the tag settings can be used for the block
+type tagBundleSettingsReader struct {
+	d *schema.ResourceDiff
+}
+
+// Get returns the value of the passed setting from the settings block
+func (r tagBundleSettingsReader) Get(key string) any {
+	return r.d.Get("settings.0." + key)
+}
+
+// GetRawConfig returns the raw configuration of the settings block
+func (r tagBundleSettingsReader) GetRawConfig() cty.Value {
+	raw := r.d.GetRawConfig()
+	if raw.IsNull() || !raw.IsKnown() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+
+	block := raw.GetAttr("settings")
+	if block.IsNull() || !block.IsKnown() || block.LengthInt() == 0 {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	return block.Index(cty.NumberIntVal(0))
+}
+
+// readTagBundleSettings sets the settings of the CONFIG tag
+func readTagBundleSettings(d *schema.ResourceData, meta any) diag.Diagnostics {
+	client := meta.(*myrasec.API)
+
+	var diags diag.Diagnostics
+
+	tagID := d.Get("config_tag_id").(int)
+	response, err := client.ListTagSettingsMap(tagID)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Error fetching tag settings",
+			Detail:   formatError(err),
+		})
+		return diags
+	}
+
+	resource := resourceMyrasecTagSettings()
+	configured := false
+	for name, value := range extractSettingsMap(response, "settings") {
+		group, _ := findSettingsGroup(name)
+		if _, ok := resource.Schema[name]; value != nil && (ok || group != nil) {
+			configured = true
+			break
+		}
+	}
+
+	if !configured {
+		d.Set("settings", []any{})
+		return diags
+	}
+
+	// the settings groups of the current block decide if the hsts settings are set as block or as flat attributes
+	settings, _ := tagBundleSettingsResourceData(d)
+	setTagSettingsData(settings, response, tagID)
+
+	if err := d.Set("settings", []any{tagBundleBlockValues(settings, tagBundleSettingsSchema())}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// buildTagBundleAssignments returns the assignments for a tag of the bundle, existing assignments of the tag are reused
+func buildTagBundleAssignments(d *schema.ResourceData, tag *myrasec.Tag) []myrasec.TagAssignment {
+	assignments := []myrasec.TagAssignment{}
+
+	add := func(assignmentType string, name string) {
+		name = myrasec.RemoveTrailingDot(name)
+		if tag != nil {
+			if existing := findTagAssignment(tag, assignmentType, name); existing != nil {
+				assignments = append(assignments, *existing)
+				return
+			}
+		}
+		assignments = append(assignments, myrasec.TagAssignment{
+			Type:          assignmentType,
+			Title:         name,
+			SubDomainName: name,
+		})
+	}
+
+	for _, name := range d.Get("domains").(*schema.Set).List() {
+		add("DOMAIN", name.(string))
+	}
+	for _, name := range d.Get("subdomains").(*schema.Set).List() {
+		add("SUBDOMAIN", name.(string))
+	}
+
+	return assignments
+}
+
+// flattenTagBundleAssignments returns the sorted subdomain and domain names of the assignments
+func flattenTagBundleAssignments(assignments []myrasec.TagAssignment) ([]string, []string) {
+	subdomains := []string{}
+	domains := []string{}
+	for _, a := range assignments {
+		name := a.SubDomainName
+		if name == "" {
+			name = a.Title
+		}
+		name = strings.ToLower(myrasec.RemoveTrailingDot(name))
+
+		if strings.EqualFold(a.Type, "DOMAIN") {
+			domains = append(domains, name)
+		} else {
+			subdomains = append(subdomains, name)
+		}
+	}
+	sort.Strings(subdomains)
+	sort.Strings(domains)
+
+	return subdomains, domains
+}
+
+// tagBundleBlockSchema returns the schema of a single tag resource without the tag_id, to use it as nested block
+func tagBundleBlockSchema(resource *schema.Resource) map[string]*schema.Schema {
+	block := map[string]*schema.Schema{}
+	for name, attr := range resource.Schema {
+		if name == "tag_id" {
+			continue
+		}
+		block[name] = attr
+	}
+	return block
+}
+
+// tagBundleResourceData returns a ResourceData of the passed resource that contains the values of a nested block,
+// so the builders of the single tag resources can be used for the nested blocks
+func tagBundleResourceData(resource *schema.Resource, values map[string]any) *schema.ResourceData {
+	d := resource.Data(nil)
+	for name, value := range values {
+		if _, ok := resource.Schema[name]; ok {
+			d.Set(name, value)
+		}
+	}
+	return d
+}
+
+// tagBundleBlockValues returns the values of a ResourceData that are part of the nested block
+func tagBundleBlockValues(d *schema.ResourceData, block map[string]*schema.Schema) map[string]any {
+	values := map[string]any{}
+	for name := range block {
+		values[name] = d.Get(name)
+	}
+	return values
+}
+
+// createTagBundleWAFRule ...
+func createTagBundleWAFRule(meta any, d *schema.ResourceData, tagID int) (int, error) {
+	rule, err := buildTagWAFRule(d)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := meta.(*myrasec.API).CreateTagWAFRule(rule, tagID)
+	if err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// prepareTagBundleWAFRule sets the hash of the applied conditions and actions of a WAF rule
+func prepareTagBundleWAFRule(d *schema.ResourceData, prefix string, values map[string]any) {
+	values["applied_hash"] = wafRuleAppliedHash(d, prefix)
+}
+
+// updateTagBundleWAFRule ...
+func updateTagBundleWAFRule(meta any, d *schema.ResourceData, tagID int) error {
+	rule, err := buildTagWAFRule(d)
+	if err != nil {
+		return err
+	}
+	_, err = meta.(*myrasec.API).UpdateTagWAFRule(rule)
+	return err
+}
+
+// deleteTagBundleWAFRule ...
+func deleteTagBundleWAFRule(meta any, d *schema.ResourceData, tagID int) error {
+	rule, err := buildTagWAFRule(d)
+	if err != nil {
+		return err
+	}
+	_, err = meta.(*myrasec.API).DeleteTagWAFRule(rule)
+	return err
+}
+
+// listTagBundleWAFRules ...
+func listTagBundleWAFRules(meta any, tagID int) ([]*schema.ResourceData, error) {
+	client := meta.(*myrasec.API)
+
+	items := []*schema.ResourceData{}
+
+	page := 1
+	pageSize := 250
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+		"page":     strconv.Itoa(page),
+	}
+
+	for {
+		params["page"] = strconv.Itoa(page)
+		res, err := client.ListTagWAFRules(tagID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range res {
+			d := resourceMyrasecTagWAFRule().Data(nil)
+			setTagWAFRuleData(d, &r)
+			items = append(items, d)
+		}
+
+		if len(res) < pageSize {
+			break
+		}
+		page++
+	}
+
+	return items, nil
+}
+
+// createTagBundleCacheSetting ...
+func createTagBundleCacheSetting(meta any, d *schema.ResourceData, tagID int) (int, error) {
+	setting, err := buildCacheSetting(d)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := meta.(*myrasec.API).CreateTagCacheSetting(setting, tagID)
+	if err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// updateTagBundleCacheSetting ...
+func updateTagBundleCacheSetting(meta any, d *schema.ResourceData, tagID int) error {
+	setting, err := buildCacheSetting(d)
+	if err != nil {
+		return err
+	}
+	_, err = meta.(*myrasec.API).UpdateTagCacheSetting(setting, tagID)
+	return err
+}
+
+// deleteTagBundleCacheSetting ...
+func deleteTagBundleCacheSetting(meta any, d *schema.ResourceData, tagID int) error {
+	setting, err := buildCacheSetting(d)
+	if err != nil {
+		return err
+	}
+	_, err = meta.(*myrasec.API).DeleteTagCacheSetting(setting, tagID)
+	return err
+}
+
+// listTagBundleCacheSettings ...
+func listTagBundleCacheSettings(meta any, tagID int) ([]*schema.ResourceData, error) {
+	client := meta.(*myrasec.API)
+
+	items := []*schema.ResourceData{}
+
+	page := 1
+	pageSize := 250
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+		"page":     strconv.Itoa(page),
+	}
+
+	for {
+		params["page"] = strconv.Itoa(page)
+		res, err := client.ListTagCacheSettings(tagID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range res {
+			d := resourceMyrasecTagCacheSetting().Data(nil)
+			setTagCacheSettingData(d, &s, tagID)
+			items = append(items, d)
+		}
+
+		if len(res) < pageSize {
+			break
+		}
+		page++
+	}
+
+	return items, nil
+}
+
+// createTagBundleInformation ...
+func createTagBundleInformation(meta any, d *schema.ResourceData, tagID int) (int, error) {
+	information, err := buildTagInformation(d)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := meta.(*myrasec.API).CreateTagInformation(information, tagID)
+	if err != nil {
+		return 0, err
+	}
+	return resp.ID, nil
+}
+
+// updateTagBundleInformation ...
+func updateTagBundleInformation(meta any, d *schema.ResourceData, tagID int) error {
+	information, err := buildTagInformation(d)
+	if err != nil {
+		return err
+	}
+	_, err = meta.(*myrasec.API).UpdateTagInformation(information, tagID)
+	return err
+}
+
+// deleteTagBundleInformation ...
+func deleteTagBundleInformation(meta any, d *schema.ResourceData, tagID int) error {
+	information, err := buildTagInformation(d)
+	if err != nil {
+		return err
+	}
+	_, err = meta.(*myrasec.API).DeleteTagInformation(information, tagID)
+	return err
+}
+
+// listTagBundleInformation ...
+func listTagBundleInformation(meta any, tagID int) ([]*schema.ResourceData, error) {
+	client := meta.(*myrasec.API)
+
+	items := []*schema.ResourceData{}
+
+	page := 1
+	pageSize := 250
+	params := map[string]string{
+		"pageSize": strconv.Itoa(pageSize),
+		"page":     strconv.Itoa(page),
+	}
+
+	for {
+		params["page"] = strconv.Itoa(page)
+		res, err := client.ListTagInformation(tagID, params)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range res {
+			d := resourceMyrasecTagInformation().Data(nil)
+			setTagInformationData(d, &i, tagID)
+			items = append(items, d)
+		}
+
+		if len(res) < pageSize {
+			break
+		}
+		page++
+	}
+
+	return items, nil
+}
@@ -23,6 +23,26 @@ func resourceMyrasecTagSettings() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		SchemaVersion: 1,
+		Schema:        addAuthoritativeSettings(addSettingsGroups(resourceMyrasecTagSettingsV0().Schema)),
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourceMyrasecTagSettingsV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceMyrasecSettingsStateUpgradeV0,
+				Version: 0,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Second),
+			Update: schema.DefaultTimeout(30 * time.Second),
+		},
+		CustomizeDiff: resourceCustomizeDiffTagSettings,
+	}
+}
+
+// resourceMyrasecTagSettingsV0 returns the flat tag settings schema (schema version 0)
+func resourceMyrasecTagSettingsV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"tag_id": {
 				Type:        schema.TypeInt,
@@ -436,11 +456,6 @@ func resourceMyrasecTagSettings() *schema.Resource {
 				},
 			},
 		},
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(30 * time.Second),
-			Update: schema.DefaultTimeout(30 * time.Second),
-		},
-		CustomizeDiff: resourceCustomizeDiffTagSettings,
 	}
 }
 
@@ -449,7 +464,7 @@ func resourceCustomizeDiffTagSettings(ctx context.Context, d *schema.ResourceDif
 	availableAttributes := []string{}
 	resource := resourceMyrasecTagSettings()
 	for name, attr := range resource.Schema {
-		if name == "tag_id" || name == "available_attributes" {
+		if name == "tag_id" || isSettingsMetaAttribute(name) || isSettingsGroup(name) {
 			continue
 		}
 
@@ -464,8 +479,15 @@ func resourceCustomizeDiffTagSettings(ctx context.Context, d *schema.ResourceDif
 			availableAttributes = append(availableAttributes, name)
 		}
 	}
+	availableAttributes = append(availableAttributes, configuredSettingsGroupAttributes(d, resource.Schema)...)
 
 	d.SetNew("available_attributes", availableAttributes)
+	customizeDiffAuthoritativeSettings(d, availableAttributes)
+
+	err := validateSettingsGroups(d)
+	if err != nil {
+		return err
+	}
 
 	return validateCookieBasedName(d)
 }
@@ -530,6 +552,7 @@ func resourceMyrasecTagSettingsRead(ctx context.Context, d *schema.ResourceData,
 	}
 
 	setTagSettingsData(d, settings, tagId.(int))
+	diags = append(diags, setUnmanagedSettingsData(d, extractSettingsMap(settings, "settings"))...)
 
 	clientMaxBodySize := d.Get("client_max_body_size")
 
@@ -642,7 +665,7 @@ func buildTagSettings(d *schema.ResourceData, clean bool) (map[string]any, error
 
 	resource := resourceMyrasecTagSettings()
 	for name, attr := range resource.Schema {
-		if name == "tag_id" || name == "available_attributes" {
+		if name == "tag_id" || isSettingsMetaAttribute(name) || isSettingsGroup(name) {
 			continue
 		}
 		value, ok := d.GetOk(name)
@@ -656,36 +679,15 @@ func buildTagSettings(d *schema.ResourceData, clean bool) (map[string]any, error
 			}
 		}
 		if ok && !clean {
-			switch attr.Type {
-			case schema.TypeBool:
-				tagSettingsMap[name] = value.(bool)
-			case schema.TypeInt:
-				tagSettingsMap[name] = value.(int)
-			case schema.TypeString:
-				if value.(string) != "" {
-					tagSettingsMap[name] = value.(string)
-				} else {
-					tagSettingsMap[name] = nil
-				}
-			case schema.TypeList:
-				settingsList := []string{}
-				for _, item := range value.([]any) {
-					settingsList = append(settingsList, item.(string))
-				}
-				tagSettingsMap[name] = settingsList
-			case schema.TypeSet:
-				settingsList := []string{}
-				for _, v := range value.(*schema.Set).List() {
-					log.Println(v)
-					settingsList = append(settingsList, v.(string))
-				}
-				tagSettingsMap[name] = settingsList
-			}
+			tagSettingsMap[name] = buildSettingsValue(attr, value)
 		} else {
 			tagSettingsMap[name] = nil
 		}
 	}
 
+	buildSettingsGroups(d, resource.Schema, tagSettingsMap, clean)
+	buildUnmanagedSettings(d, tagSettingsMap)
+
 	return tagSettingsMap, nil
 }
 
@@ -697,14 +699,26 @@ func setTagSettingsData(d *schema.ResourceData, settingsData any, tagId int) {
 	log.Println(settings)
 
 	resource := resourceMyrasecSettings().Schema
+	blockGroups := settingsGroupsInState(d)
 	availableAttributes := []string{}
+	groupValues := map[string]map[string]any{}
 	for k, v := range (*settings)["settings"].(map[string]any) {
-		d.Set(k, v)
+		if group, attribute := findSettingsGroup(k); group != nil && blockGroups[group.Name] {
+			if v != nil {
+				if _, ok := groupValues[group.Name]; !ok {
+					groupValues[group.Name] = map[string]any{}
+				}
+				groupValues[group.Name][attribute] = v
+			}
+		} else {
+			d.Set(k, v)
+		}
 		doAppend := appendAvailableAttributes(v, k, resource)
 		if doAppend {
 			availableAttributes = append(availableAttributes, k)
 		}
 	}
+	setSettingsGroupsData(d, groupValues)
 	d.Set("available_attributes", availableAttributes)
 
 	method := d.Get("balancing_method")
@@ -93,7 +93,19 @@ func resourceMyrasecTagWAFRule() *schema.Resource {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				Description: "",
+				Description: "Keep the rule in sync with the template it was created from.",
+			},
+			"template_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "report",
+				ValidateFunc: validation.StringInSlice([]string{"follow", "report"}, false),
+				Description:  "Behavior for conditions and actions that Myra updated from the template of a synced rule. `follow` accepts the changes, `report` shows them as drift.",
+			},
+			"applied_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hash of the conditions and actions that were applied by Terraform.",
 			},
 			"template": {
 				Type:        schema.TypeBool,
@@ -114,8 +126,9 @@ func resourceMyrasecTagWAFRule() *schema.Resource {
 				Description: "Define wether this rule is enabled or not.",
 			},
 			"conditions": {
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:             schema.TypeList,
+				Optional:         true,
+				DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"condition_id": {
@@ -132,32 +145,38 @@ func resourceMyrasecTagWAFRule() *schema.Resource {
 							Computed: true,
 						},
 						"category": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							Computed:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 						"matching_type": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 						"name": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 						"key": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 						"value": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 					},
 				},
 			},
 			"actions": {
-				Type:     schema.TypeList,
-				Required: true,
+				Type:             schema.TypeList,
+				Required:         true,
+				DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"available_phases": {
@@ -169,16 +188,19 @@ func resourceMyrasecTagWAFRule() *schema.Resource {
 							Computed: true,
 						},
 						"type": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:             schema.TypeString,
+							Required:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 						"custom_key": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 						"value": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressWAFRuleTemplateSyncDiff,
 						},
 					},
 				},
@@ -194,6 +216,18 @@ func resourceMyrasecTagWAFRule() *schema.Resource {
 			if err != nil {
 				return err
 			}
+
+			if !rd.NewValueKnown("conditions") || !rd.NewValueKnown("actions") {
+				return rd.SetNewComputed("applied_hash")
+			}
+			// rules that were created by a previous version of the provider have no hash yet, it is stored by the next refresh
+			if rd.Id() != "" && rd.Get("applied_hash").(string) == "" && !rd.HasChanges("conditions", "actions") {
+				return nil
+			}
+			appliedHash := createContentHash(formatWAFRuleTemplateFields(rd.Get("conditions").([]any), rd.Get("actions").([]any)))
+			if appliedHash != rd.Get("applied_hash").(string) {
+				return rd.SetNew("applied_hash", appliedHash)
+			}
 			return nil
 		},
 		Timeouts: &schema.ResourceTimeout{
@@ -244,6 +278,7 @@ func resourceMyrasecTagWAFRuleCreate(ctx context.Context, d *schema.ResourceData
 		return diags
 	}
 
+	d.Set("applied_hash", wafRuleAppliedHash(d, ""))
 	d.SetId(fmt.Sprintf("%d", resp.ID))
 	return resourceMyrasecTagWAFRuleRead(ctx, d, meta)
 }
@@ -277,8 +312,14 @@ func resourceMyrasecTagWAFRuleRead(ctx context.Context, d *schema.ResourceData,
 		return diags
 	}
 
+	diags = append(diags, checkWAFRuleTemplateSync(d, rule)...)
 	setTagWAFRuleData(d, rule)
 
+	// rules that were created by a previous version of the provider have no hash yet, the current conditions and actions are treated as applied
+	if d.Get("applied_hash").(string) == "" {
+		d.Set("applied_hash", createContentHash(formatWAFRuleTemplateFields(d.Get("conditions").([]any), d.Get("actions").([]any))))
+	}
+
 	return diags
 }
 
@@ -314,6 +355,7 @@ func resourceMyrasecTagWAFRuleUpdate(ctx context.Context, d *schema.ResourceData
 	// NOTE: This is a temporary "fix"
 	time.Sleep(200 * time.Millisecond)
 
+	appliedHash := wafRuleAppliedHash(d, "")
 	rule, err = client.UpdateTagWAFRule(rule)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -325,6 +367,7 @@ func resourceMyrasecTagWAFRuleUpdate(ctx context.Context, d *schema.ResourceData
 	}
 
 	setTagWAFRuleData(d, rule)
+	d.Set("applied_hash", appliedHash)
 
 	return diags
 }
@@ -537,3 +580,85 @@ func setTagWAFRuleData(d *schema.ResourceData, rule *myrasec.TagWAFRule) {
 	actions := createActions(rule.Actions)
 	d.Set("actions", actions)
 }
+
+// suppressWAFRuleTemplateSyncDiff suppresses the diff of the conditions and actions of an existing rule that follows its template (sync = true and template_policy = "follow").
+// Only changes that Myra made from the template are suppressed: the configured conditions and actions still have to match the last applied ones.
+func suppressWAFRuleTemplateSyncDiff(k, old, new string, d *schema.ResourceData) bool {
+	// the prefix is empty for myrasec_tag_waf_rule and e.g. "waf_rule.0." for nested rules
+	prefix := ""
+	for _, block := range []string{"conditions", "actions"} {
+		if i := strings.Index(k, block); i >= 0 {
+			prefix = k[:i]
+			break
+		}
+	}
+
+	ruleID, _ := d.Get(prefix + "rule_id").(int)
+	sync, _ := d.Get(prefix + "sync").(bool)
+	policy, _ := d.Get(prefix + "template_policy").(string)
+	if ruleID <= 0 || !sync || policy != "follow" {
+		return false
+	}
+
+	// the configuration is read here, the state contains the conditions and actions that were updated from the template
+	conditions, _ := d.Get(prefix + "conditions").([]any)
+	actions, _ := d.Get(prefix + "actions").([]any)
+	appliedHash, _ := d.Get(prefix + "applied_hash").(string)
+
+	return appliedHash == createContentHash(formatWAFRuleTemplateFields(conditions, actions))
+}
+
+// wafRuleAppliedHash returns the hash of the conditions and actions that are applied. The hash is kept if they are not changed,
+// as the current values may be the ones that were updated from the template.
+func wafRuleAppliedHash(d *schema.ResourceData, prefix string) string {
+	if !d.HasChanges(prefix+"conditions", prefix+"actions") {
+		appliedHash, _ := d.Get(prefix + "applied_hash").(string)
+		return appliedHash
+	}
+
+	conditions, _ := d.Get(prefix + "conditions").([]any)
+	actions, _ := d.Get(prefix + "actions").([]any)
+	return createContentHash(formatWAFRuleTemplateFields(conditions, actions))
+}
+
+// checkWAFRuleTemplateSync reports conditions and actions that Myra updated from the template of a synced rule
+func checkWAFRuleTemplateSync(d *schema.ResourceData, rule *myrasec.TagWAFRule) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	conditions := d.Get("conditions").([]any)
+	actions := d.Get("actions").([]any)
+	if !rule.Sync || (len(conditions) == 0 && len(actions) == 0) {
+		return diags
+	}
+
+	if formatWAFRuleTemplateFields(conditions, actions) == formatWAFRuleTemplateFields(createConditions(rule.Conditions), createActions(rule.Actions)) {
+		return diags
+	}
+
+	if d.Get("template_policy").(string) == "follow" {
+		log.Printf("[INFO] WAF rule [%d] was updated from its template", rule.ID)
+		return diags
+	}
+
+	diags = append(diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "WAF rule updated from template",
+		Detail:   fmt.Sprintf("The conditions or actions of the WAF rule [%s] (%d) were updated from its template. The changes are shown as drift and the next apply reverts them. Set template_policy = \"follow\" to accept the changes of the template.", rule.Name, rule.ID),
+	})
+
+	return diags
+}
+
+// formatWAFRuleTemplateFields returns the fields of the conditions and actions that are updated from the template as string
+func formatWAFRuleTemplateFields(conditions []any, actions []any) string {
+	fields := []string{}
+	for _, c := range conditions {
+		condition := c.(map[string]any)
+		fields = append(fields, fmt.Sprintf("condition:%v:%v:%v:%v", condition["matching_type"], condition["name"], condition["key"], condition["value"]))
+	}
+	for _, a := range actions {
+		action := a.(map[string]any)
+		fields = append(fields, fmt.Sprintf("action:%v:%v:%v", action["type"], action["custom_key"], action["value"]))
+	}
+	return strings.Join(fields, "\n")
+}
@@ -102,9 +102,24 @@ func resourceMyrasecWAFRule() *schema.Resource {
 				Description: "Type of the rule.",
 			},
 			"expire_date": {
-				Type:        schema.TypeString,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateExpireDate,
+				DiffSuppressFunc: suppressEquivalentDates,
+				Description:      "Expire date schedules the deaktivation of the WAF rule. If none is set, the rule will be active until manual deactivation.",
+			},
+			"expiry_warning_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Emit a warning when the WAF rule expires within the given number of days.",
+			},
+			"remove_on_expiry": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Expire date schedules the deaktivation of the WAF rule. If none is set, the rule will be active until manual deactivation.",
+				Default:     false,
+				Description: "Treat the WAF rule as removed once the expire date has passed.",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -226,7 +241,8 @@ func resourceMyrasecWAFRule() *schema.Resource {
 			if err != nil {
 				return err
 			}
-			return nil
+
+			return validateRemoveOnExpiry(rd, "WAF rule")
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Second),
@@ -387,8 +403,16 @@ func resourceMyrasecWAFRuleRead(ctx context.Context, d *schema.ResourceData, met
 		return diags
 	}
 
+	if d.Get("remove_on_expiry").(bool) && isExpired(rule.ExpireDate) {
+		log.Printf("[INFO] WAF rule [%d] has expired and is removed from the state", ruleID)
+		d.SetId("")
+		return diags
+	}
+
 	setWAFRuleData(d, rule, domainID)
 
+	diags = append(diags, checkExpireDate(fmt.Sprintf("WAF rule [%s]", rule.Name), rule.ExpireDate, d.Get("expiry_warning_days").(int), "Remove it from the configuration or update the expire_date.")...)
+
 	return diags
 }
 
@@ -684,6 +708,10 @@ func setWAFRuleData(d *schema.ResourceData, rule *myrasec.WAFRule, domainID int)
 	d.Set("domain_id", domainID)
 	d.Set("rule_type", rule.RuleType)
 
+	if rule.ExpireDate != nil {
+		d.Set("expire_date", rule.ExpireDate.Format(time.RFC3339))
+	}
+
 	conditions := createConditions(rule.Conditions)
 	d.Set("conditions", conditions)
 
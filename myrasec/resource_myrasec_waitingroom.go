@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/Myra-Security-GmbH/myrasec-go/v2/pkg/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // resourceMyrasecWaitingRoom ...
@@ -62,15 +64,12 @@ func resourceMyrasecWaitingRoom() *schema.Resource {
 				Description: "Name of the waiting room.",
 			},
 			"content": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Content of the waiting room.",
-				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
-					oldHash := d.Get("content_hash")
-					newHash := createContentHash(newValue)
-					return oldHash == newHash
-				},
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Content of the waiting room.",
+				DiffSuppressFunc: suppressRenderedContentDiff,
 			},
+			"content_source_dir": htmlContentSourceDirSchema(),
 			"content_hash": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -81,40 +80,230 @@ func resourceMyrasecWaitingRoom() *schema.Resource {
 				Required:    true,
 				Description: "Paths of the waiting room.",
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validateWaitingRoomPath,
 				},
 			},
 			"max_concurrent": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     1000,
-				Description: "Maximum amount of concurrent requests.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				Description:  "Maximum amount of concurrent requests.",
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"session_timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     50,
-				Description: "Session timeout.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				Description:  "Session timeout.",
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"wait_refresh": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Default:     60,
-				Description: "The affected timeframe in seconds for the waiting room.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      60,
+				Description:  "The affected timeframe in seconds for the waiting room.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"active_from": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The waiting room is created at this date.",
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentDates,
+			},
+			"active_until": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The waiting room is removed at this date.",
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentDates,
+			},
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Status if the waiting room exists.",
 			},
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(30 * time.Second),
 			Update: schema.DefaultTimeout(30 * time.Second),
 		},
+		CustomizeDiff: resourceCustomizeDiffWaitingRoom,
 	}
 }
 
 // Global lock to enforce sequential execution
 var creationLock sync.Mutex
 
+// resourceCustomizeDiffWaitingRoom validates the settings and paths and plans the activation of the waiting room
+func resourceCustomizeDiffWaitingRoom(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if err := resourceCustomizeDiffHTMLContent(ctx, d, meta); err != nil {
+		return err
+	}
+
+	if !d.NewValueKnown("active_from") || !d.NewValueKnown("active_until") {
+		return d.SetNewComputed("active")
+	}
+
+	activeFrom, _ := types.ParseDate(d.Get("active_from").(string))
+	activeUntil, _ := types.ParseDate(d.Get("active_until").(string))
+	if activeFrom != nil && activeUntil != nil && !activeUntil.After(activeFrom.Time) {
+		return fmt.Errorf("active_until must be after active_from")
+	}
+	if d.Id() == "" && isExpired(activeUntil) {
+		return fmt.Errorf("this waiting room can not be created, active_until is in the past")
+	}
+
+	active := isWaitingRoomActive(activeFrom, activeUntil, time.Now())
+	if d.Id() == "" || active != d.Get("active").(bool) {
+		if err := d.SetNew("active", active); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && active != d.Get("active").(bool) {
+		if !active {
+			return d.SetNew("waitingroom_id", 0)
+		}
+		for _, k := range []string{"waitingroom_id", "vhost_id", "created", "modified"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	if active && (d.Id() == "" || d.HasChange("paths") || d.HasChange("active")) && d.NewValueKnown("paths") && d.NewValueKnown("subdomain_name") {
+		return checkWaitingRoomPathOverlap(meta, d.Get("subdomain_name").(string), d.Get("waitingroom_id").(int), d.Get("paths").(*schema.Set))
+	}
+
+	return nil
+}
+
+// isWaitingRoomActive checks if the passed time is within the activation window
+func isWaitingRoomActive(activeFrom *types.DateTime, activeUntil *types.DateTime, now time.Time) bool {
+	if activeFrom != nil && now.Before(activeFrom.Time) {
+		return false
+	}
+	if activeUntil != nil && !now.Before(activeUntil.Time) {
+		return false
+	}
+	return true
+}
+
+// isWaitingRoomActiveNow checks if the waiting room is within its activation window
+func isWaitingRoomActiveNow(d *schema.ResourceData) bool {
+	activeFrom, _ := types.ParseDate(d.Get("active_from").(string))
+	activeUntil, _ := types.ParseDate(d.Get("active_until").(string))
+	return isWaitingRoomActive(activeFrom, activeUntil, time.Now())
+}
+
+// validateWaitingRoomPath checks that the path is a well-formed regular expression without whitespace. Myra evaluates the
+// paths as PCRE, so only the escapes, the groups and the character classes are checked.
+func validateWaitingRoomPath(i any, k string) (warnings []string, errors []error) {
+	path := i.(string)
+	if path == "" || strings.ContainsAny(path, " \t\r\n") {
+		errors = append(errors, fmt.Errorf("%q must not be empty or contain whitespace, got [%s]", k, path))
+		return warnings, errors
+	}
+
+	if err := checkWaitingRoomPathSyntax(path); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a well-formed regular expression, got [%s]: %s", k, path, err.Error()))
+	}
+	return warnings, errors
+}
+
+// checkWaitingRoomPathSyntax checks that the escapes are complete and that the groups and character classes are closed
+func checkWaitingRoomPathSyntax(path string) error {
+	groups := 0
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			if i == len(path)-1 {
+				return fmt.Errorf("trailing backslash")
+			}
+			i++
+		case '[':
+			// a "]" directly after "[" or "[^" is part of the class
+			j := i + 1
+			if j < len(path) && path[j] == '^' {
+				j++
+			}
+			if j < len(path) && path[j] == ']' {
+				j++
+			}
+			for ; j < len(path) && path[j] != ']'; j++ {
+				if path[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(path) {
+				return fmt.Errorf("missing closing ]")
+			}
+			i = j
+		case '(':
+			groups++
+		case ')':
+			if groups == 0 {
+				return fmt.Errorf("unexpected )")
+			}
+			groups--
+		}
+	}
+
+	if groups > 0 {
+		return fmt.Errorf("missing closing )")
+	}
+	return nil
+}
+
+// checkWaitingRoomPathOverlap checks that the paths do not overlap with the paths of another waiting room of the subdomain
+func checkWaitingRoomPathOverlap(meta any, subDomainName string, waitingRoomID int, paths *schema.Set) error {
+	waitingRooms, diags := listWaitingRoomsForSubDomain(meta, subDomainName, map[string]string{})
+	if diags.HasError() {
+		return fmt.Errorf("unable to load waiting rooms for subdomain [%s]", subDomainName)
+	}
+
+	for _, wr := range waitingRooms {
+		if wr.ID == waitingRoomID {
+			continue
+		}
+		for _, p := range paths.List() {
+			for _, other := range wr.Paths {
+				if waitingRoomPathsOverlap(p.(string), other) {
+					return fmt.Errorf("path [%s] overlaps with path [%s] of waiting room [%s] (ID = %d) on subdomain [%s]", p.(string), other, wr.Name, wr.ID, subDomainName)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// waitingRoomPathsOverlap checks if the paths are equal or one of them matches all paths. Myra evaluates the paths as PCRE,
+// so other overlaps are not detected
+func waitingRoomPathsOverlap(a string, b string) bool {
+	return a == b || isWaitingRoomCatchAllPath(a) || isWaitingRoomCatchAllPath(b)
+}
+
+// isWaitingRoomCatchAllPath checks if the path matches all paths of the subdomain
+func isWaitingRoomCatchAllPath(path string) bool {
+	return StringInSlice(path, []string{".", ".*", "^.*", "^/", "/", "^/.*"})
+}
+
 // resourceMyrasecWaitingRoomCreate ...
 func resourceMyrasecWaitingRoomCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	if !isWaitingRoomActiveNow(d) {
+		// the waiting room is created by the first apply within the activation window
+		d.SetId(fmt.Sprintf("%s:%d", myrasec.RemoveTrailingDot(d.Get("subdomain_name").(string)), time.Now().Unix()))
+		d.Set("waitingroom_id", 0)
+		d.Set("active", false)
+		return nil
+	}
+
+	return createWaitingRoom(ctx, d, meta)
+}
+
+// createWaitingRoom ...
+func createWaitingRoom(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*myrasec.API)
 
 	var diags diag.Diagnostics
@@ -128,6 +317,8 @@ func resourceMyrasecWaitingRoomCreate(ctx context.Context, d *schema.ResourceDat
 		})
 		return diags
 	}
+	// the ID of a waiting room that was removed after its activation window is not reused
+	waitingroom.ID = 0
 
 	creationLock.Lock() // Ensure only one resource is created at a time
 	defer creationLock.Unlock()
@@ -145,6 +336,7 @@ func resourceMyrasecWaitingRoomCreate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	d.SetId(fmt.Sprintf("%d", resp.ID))
+	d.Set("active", true)
 	return resourceMyrasecWaitingRoomRead(ctx, d, meta)
 }
 
@@ -154,6 +346,10 @@ func resourceMyrasecWaitingRoomRead(ctx context.Context, d *schema.ResourceData,
 
 	client := meta.(*myrasec.API)
 
+	if !d.Get("active").(bool) && d.Get("waitingroom_id").(int) == 0 {
+		return diags
+	}
+
 	waitingRoomID, err := strconv.Atoi(d.Id())
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -176,6 +372,15 @@ func resourceMyrasecWaitingRoomRead(ctx context.Context, d *schema.ResourceData,
 
 	setWaitingRoomData(d, waitingRoom)
 
+	activeUntil, _ := types.ParseDate(d.Get("active_until").(string))
+	if isExpired(activeUntil) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Waiting room is still active",
+			Detail:   fmt.Sprintf("The waiting room [%s] should have been removed at [%s]. Apply the configuration to remove it.", waitingRoom.Name, activeUntil.Format(time.RFC3339)),
+		})
+	}
+
 	return diags
 }
 
@@ -185,6 +390,25 @@ func resourceMyrasecWaitingRoomUpdate(ctx context.Context, d *schema.ResourceDat
 
 	var diags diag.Diagnostics
 
+	oldWaitingRoomID, _ := d.GetChange("waitingroom_id")
+	exists := oldWaitingRoomID.(int) > 0
+	if !isWaitingRoomActiveNow(d) {
+		if exists {
+			log.Printf("[INFO] Removing waiting room, the activation window has ended: %v", d.Id())
+			diags = deleteWaitingRoom(d, meta)
+			if diags.HasError() {
+				return diags
+			}
+		}
+		d.Set("waitingroom_id", 0)
+		d.Set("active", false)
+		return diags
+	}
+
+	if !exists {
+		return createWaitingRoom(ctx, d, meta)
+	}
+
 	waitingRoomID, err := strconv.Atoi(d.Id())
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -229,6 +453,17 @@ func resourceMyrasecWaitingRoomUpdate(ctx context.Context, d *schema.ResourceDat
 
 // resourceMyrasecWaitingRoomDelete ...
 func resourceMyrasecWaitingRoomDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !d.Get("active").(bool) && d.Get("waitingroom_id").(int) == 0 {
+		return diags
+	}
+
+	return deleteWaitingRoom(d, meta)
+}
+
+// deleteWaitingRoom ...
+func deleteWaitingRoom(d *schema.ResourceData, meta any) diag.Diagnostics {
 	client := meta.(*myrasec.API)
 
 	var diags diag.Diagnostics
@@ -292,6 +527,11 @@ func resourceMyrasecWaitingRoomImport(ctx context.Context, d *schema.ResourceDat
 // buildWaitingRoom ...
 func buildWaitingRoom(d *schema.ResourceData, meta any) (*myrasec.WaitingRoom, error) {
 
+	content, err := renderHTMLContent(d.Get("content").(string), d.Get("content_source_dir").(string))
+	if err != nil {
+		return nil, err
+	}
+
 	waitingroom := &myrasec.WaitingRoom{
 		Name:           d.Get("name").(string),
 		SubDomainName:  d.Get("subdomain_name").(string),
@@ -299,7 +539,7 @@ func buildWaitingRoom(d *schema.ResourceData, meta any) (*myrasec.WaitingRoom, e
 		MaxConcurrent:  d.Get("max_concurrent").(int),
 		SessionTimeout: d.Get("session_timeout").(int),
 		WaitRefresh:    d.Get("wait_refresh").(int),
-		Content:        d.Get("content").(string),
+		Content:        content,
 	}
 
 	if waitingroom.VhostId == 0 {
@@ -412,4 +652,5 @@ func setWaitingRoomData(d *schema.ResourceData, waitingRoom *myrasec.WaitingRoom
 	d.Set("paths", waitingRoom.Paths)
 	d.Set("content", "")
 	d.Set("content_hash", createContentHash(waitingRoom.Content))
+	d.Set("active", true)
 }